@@ -0,0 +1,82 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+//Interaction One exchange with the service saved to the cassette.
+type Interaction struct {
+	PathMethod string      `json:"path_method"`
+	Request    string      `json:"request"`
+	Code       int         `json:"code,omitempty"`
+	Header     http.Header `json:"header,omitempty"`
+	Response   string      `json:"response,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+//Cassette Recorded exchanges with the service in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+//LoadCassette Read the cassette file written by RecordClient.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("client cassette: %w", err)
+	}
+	cassette := &Cassette{}
+	if err = json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("client cassette: %w", err)
+	}
+	return cassette, nil
+}
+
+//Save Write the cassette to the file.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("client cassette: %w", err)
+	}
+	if err = ioutil.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("client cassette: %w", err)
+	}
+	return nil
+}
+
+//Matcher Check if the recorded interaction answers the request being replayed.
+type Matcher func(request, recorded Interaction) bool
+
+//MatchPathMethod Match the interactions by the method of the service.
+func MatchPathMethod(request, recorded Interaction) bool {
+	return request.PathMethod == recorded.PathMethod
+}
+
+//MatchBody Match the interactions by the method and the body, the signature of the token is ignored since it differs on every signing.
+func MatchBody(request, recorded Interaction) bool {
+	return MatchPathMethod(request, recorded) && unsignedBody(request.Request) == unsignedBody(recorded.Request)
+}
+
+func unsignedBody(body string) string {
+	token := struct {
+		Token string `json:"token"`
+	}{}
+	if err := json.Unmarshal([]byte(body), &token); err != nil || token.Token == "" {
+		return body
+	}
+	if i := strings.LastIndex(token.Token, "."); i >= 0 {
+		return token.Token[:i]
+	}
+	return token.Token
+}
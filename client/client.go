@@ -8,16 +8,34 @@
 package client
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/crypto"
 	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/tracing"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/label"
+)
+
+var (
+	ErrOGRNMismatch = errors.New("client: ogrn not matched with certificate")
 )
 
 type options struct {
 	apiBase string
 	ogrn    string
 	kpp     string
+	crypto  sspvo.Crypto
+	warn    func(err error)
+	logger  sspvo.Logger
+	tracer  trace.TracerProvider
 }
 
 type Option func(*options)
@@ -43,12 +61,41 @@ func SetKPP(kpp string) Option {
 	}
 }
 
+//SetCrypto To set the crypto option, its certificate is checked against the ogrn option at startup.
+func SetCrypto(crypto sspvo.Crypto) Option {
+	return func(o *options) {
+		o.crypto = crypto
+	}
+}
+
+//SetWarn To set the warn option called with non-fatal problems found at startup,
+//by default they are written to the logger option at the warn level, without the logger nothing is written.
+func SetWarn(warn func(err error)) Option {
+	return func(o *options) {
+		o.warn = warn
+	}
+}
+
+//SetLogger To set the logger option that receives the events of sending and verification of the responses, by default nothing is logged.
+func SetLogger(logger sspvo.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+//SetTracerProvider To set the tracer provider option of the spans of sending, signing and verification, by default the global provider.
+func SetTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracer = provider
+	}
+}
+
 //Client Basic structure that implements the sspvo.Client interface.
 type Client struct {
 	opts *options
 }
 
-//NewClient Creating a new base Client, supports the following options: SetOGRN, SetKPP, SetAPIBase.
+//NewClient Creating a new base Client, supports the following options: SetOGRN, SetKPP, SetAPIBase, SetCrypto, SetWarn, SetLogger, SetTracerProvider.
 func NewClient(opts ...Option) (Client, error) {
 	o := options{}
 	for _, opt := range opts {
@@ -63,16 +110,175 @@ func NewClient(opts ...Option) (Client, error) {
 		return Client{}, errors.New("client: kpp not set")
 	}
 
+	if o.crypto != nil {
+		if err := checkCert(o.ogrn, o.crypto); err != nil {
+			o.warning(err)
+		}
+	}
+
 	return Client{
 		opts: &o,
 	}, nil
 }
 
+//warning Pass the non-fatal problem to the warn option, otherwise to the logger.
+func (o *options) warning(err error) {
+	switch {
+	case o.warn != nil:
+		o.warn(err)
+	case o.logger != nil:
+		o.logger.Log(context.Background(), sspvo.LogWarn, sspvo.EventClientWarning,
+			sspvo.LogField{Key: sspvo.LogKeyError, Value: err.Error()})
+	}
+}
+
+func checkCert(ogrn string, c sspvo.Crypto) error {
+	info, err := crypto.ParseCertInfo(c.GetCert())
+	if err != nil {
+		return fmt.Errorf("client: %w", err)
+	}
+
+	certOGRN := info.Subject.OGRN
+	if certOGRN == "" {
+		certOGRN = info.Subject.OGRNIP
+	}
+	if certOGRN != "" && certOGRN != ogrn {
+		return fmt.Errorf("%w: configured %q, certificate %q (%s)", ErrOGRNMismatch, ogrn, certOGRN, info.Subject)
+	}
+	return nil
+}
+
 func (c *Client) PrepareBody(msg sspvo.Message) ([]byte, error) {
+	return c.prepareBody(context.Background(), msg)
+}
+
+//prepareBody Prepare the body within the context of the sending, the messages implementing sspvo.ContextMessage trace the signing.
+func (c *Client) prepareBody(ctx context.Context, msg sspvo.Message) ([]byte, error) {
+	msg = msg.UpdateJWTFields(message.SetKPP(c.opts.kpp), message.SetOGRN(c.opts.ogrn))
+	if contextMessage, ok := msg.(sspvo.ContextMessage); ok {
+		return contextMessage.GetJWTContext(ctx)
+	}
+	return msg.GetJWT()
+}
+
+//send Run the sending of the message: the span, the preparation of the response and the body, the verification and the log,
+//post delivers the prepared body and returns the response of the service.
+func (c *Client) send(ctx context.Context, msg sspvo.Message, post func(ctx context.Context, body []byte) (*sspvo.ClientResponse, error)) (res sspvo.Response) {
+
+	ctx, span := c.startSend(ctx, msg)
+	res = msg.Response()
+	c.prepareResponse(ctx, res)
+	defer func(start time.Time) {
+		c.verifyResponse(res)
+		c.logSent(ctx, msg, res, time.Since(start))
+		c.endSend(span, res)
+	}(time.Now())
 
-	body, err := msg.UpdateJWTFields(message.SetKPP(c.opts.kpp), message.SetOGRN(c.opts.ogrn)).GetJWT()
+	body, err := c.prepareBody(ctx, msg)
 	if err != nil {
-		return nil, err
+		res.SetError(fmt.Errorf("client prepare body: %w", err))
+		return
+	}
+
+	resp, err := post(ctx, body)
+	if err != nil {
+		res.SetError(err)
+		return
+	}
+	res.SetClientResponse(resp)
+	return
+}
+
+//prepareResponse Pass the logger and the context of the sending to the response of the message.
+func (c *Client) prepareResponse(ctx context.Context, res sspvo.Response) {
+	if contextResponse, ok := res.(sspvo.ContextResponse); ok {
+		contextResponse.SetContext(ctx)
+	}
+	if c.opts.logger == nil {
+		return
+	}
+	if loggable, ok := res.(sspvo.Loggable); ok {
+		loggable.SetLogger(c.opts.logger)
+	}
+}
+
+//verifyResponse Verify the signature of the response within the span of the sending, the response keeps the result for Data.
+func (c *Client) verifyResponse(res sspvo.Response) {
+	verifiable, ok := res.(sspvo.VerifiableResponse)
+	if ok && res.Error() == nil && res.ClientResponse() != nil {
+		_ = verifiable.Verify()
+	}
+}
+
+//startSend Start the span of the sending of the message.
+func (c *Client) startSend(ctx context.Context, msg sspvo.Message) (context.Context, trace.Span) {
+	provider := c.opts.tracer
+	if provider == nil {
+		provider = global.TracerProvider()
+	}
+	attrs := []label.KeyValue{tracing.AttrPathMethod.String(msg.PathMethod())}
+	if fieldsMessage, ok := msg.(sspvo.FieldsMessage); ok {
+		attrs = append(attrs, tracing.FieldsAttributes(fieldsMessage.JWTFields())...)
+	}
+	return provider.Tracer(tracing.InstrumentationName).Start(ctx, tracing.SpanSend,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+//endSend End the span of the sending with the response code.
+func (c *Client) endSend(span trace.Span, res sspvo.Response) {
+	if !span.IsRecording() {
+		span.End()
+		return
+	}
+	if resp := res.ClientResponse(); resp != nil {
+		span.SetAttributes(tracing.AttrStatusCode.Int(resp.Code))
+	}
+	tracing.End(span, res.Error())
+}
+
+//logSent Write the event about the sent message.
+func (c *Client) logSent(ctx context.Context, msg sspvo.Message, res sspvo.Response, latency time.Duration) {
+	if c.opts.logger == nil {
+		return
+	}
+
+	fields := append(messageLogFields(msg), sspvo.LogField{Key: sspvo.LogKeyLatency, Value: latency})
+	level := sspvo.LogInfo
+	if resp := res.ClientResponse(); resp != nil {
+		fields = append(fields, sspvo.LogField{Key: sspvo.LogKeyCode, Value: resp.Code})
+		if resp.Code != http.StatusOK {
+			level = sspvo.LogWarn
+		}
+	}
+	if err := res.Error(); err != nil {
+		fields = append(fields, sspvo.LogField{Key: sspvo.LogKeyError, Value: err.Error()})
+		level = sspvo.LogError
+	}
+	c.opts.logger.Log(ctx, level, sspvo.EventMessageSent, fields...)
+}
+
+var messageLogKeys = []struct {
+	field sspvo.FieldName
+	key   string
+}{
+	{field: sspvo.FieldAction, key: sspvo.LogKeyAction},
+	{field: sspvo.FieldDataType, key: sspvo.LogKeyDatatype},
+	{field: sspvo.FieldCLS, key: sspvo.LogKeyCLS},
+	{field: sspvo.FieldIdJWT, key: sspvo.LogKeyIDJWT},
+}
+
+//messageLogFields Describe the message for the events, the personal data of the payload is never included.
+func messageLogFields(msg sspvo.Message) []sspvo.LogField {
+	fields := []sspvo.LogField{{Key: sspvo.LogKeyPathMethod, Value: msg.PathMethod()}}
+	fieldsMessage, ok := msg.(sspvo.FieldsMessage)
+	if !ok {
+		return fields
+	}
+	jwtFields := fieldsMessage.JWTFields()
+	for _, k := range messageLogKeys {
+		if v, ok := jwtFields[k.field]; ok {
+			fields = append(fields, sspvo.LogField{Key: k.key, Value: v})
+		}
 	}
-	return body, err
+	return fields
 }
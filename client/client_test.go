@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/crypto"
+	"github.com/ftomza/go-sspvo/crypto/gostgen"
 )
 
 type Message struct {
@@ -179,3 +181,78 @@ func TestClient_PrepareBody(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClient_checkCert(t *testing.T) {
+	ca, err := gostgen.NewCA(gostgen.SetSubject(gostgen.Subject{OGRN: "1027700000000"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := gostgen.NewCA(gostgen.SetSubject(gostgen.Subject{OGRNIP: "312345678901234"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	anonymous, err := gostgen.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		ogrn string
+		cert string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantWarn error
+	}{
+		{
+			name:     "ok",
+			args:     args{ogrn: "1027700000000", cert: ca.CertPEM()},
+			wantWarn: nil,
+		},
+		{
+			name:     "ok ogrnip",
+			args:     args{ogrn: "312345678901234", cert: ip.CertPEM()},
+			wantWarn: nil,
+		},
+		{
+			name:     "ok without ogrn",
+			args:     args{ogrn: "test", cert: anonymous.CertPEM()},
+			wantWarn: nil,
+		},
+		{
+			name:     "warn mismatch",
+			args:     args{ogrn: "test", cert: ca.CertPEM()},
+			wantWarn: ErrOGRNMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := crypto.NewGostCrypto(crypto.SetCert(tt.args.cert))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotWarn error
+			_, err = NewClient(SetOGRN(tt.args.ogrn), SetKPP("KPP"), SetCrypto(c), SetWarn(func(err error) {
+				gotWarn = err
+			}))
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if !errors.Is(gotWarn, tt.wantWarn) {
+				t.Errorf("NewClient() warn = %v, want %v", gotWarn, tt.wantWarn)
+			}
+
+			logger := &testLogger{}
+			if _, err = NewClient(SetOGRN(tt.args.ogrn), SetKPP("KPP"), SetCrypto(c), SetLogger(logger)); err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if got := len(logger.events) > 0; got != (tt.wantWarn != nil) {
+				t.Fatalf("NewClient() logger events = %v", logger.events)
+			}
+			if tt.wantWarn != nil && (logger.events[0] != sspvo.EventClientWarning || logger.levels[0] != sspvo.LogWarn) {
+				t.Errorf("NewClient() logger = %v %v", logger.events, logger.levels)
+			}
+		})
+	}
+}
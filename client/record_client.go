@@ -0,0 +1,146 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+type recordOptions struct {
+	redact func(interaction *Interaction)
+}
+
+type RecordOption func(*recordOptions)
+
+//SetRedact To set the redact option called with every interaction before it is written, used to remove personal data from the cassette.
+func SetRedact(redact func(interaction *Interaction)) RecordOption {
+	return func(o *recordOptions) {
+		o.redact = redact
+	}
+}
+
+//RedactWith Receive the redact option of SetRedact that passes the request and the response through redact, e.g. redact.Redact.
+func RedactWith(redact func(data []byte) []byte) func(interaction *Interaction) {
+	return func(interaction *Interaction) {
+		interaction.Request = string(redact([]byte(interaction.Request)))
+		interaction.Response = string(redact([]byte(interaction.Response)))
+	}
+}
+
+//RecordClient Client structure that implements the sspvo.Client interface and records every exchange of the wrapped client,
+//the cassette is written to the file by Save and Close.
+type RecordClient struct {
+	client sspvo.Client
+	path   string
+	opts   *recordOptions
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+//NewRecordClient Creating a new RecordClient that writes the cassette to the path, supports the following options: SetRedact.
+func NewRecordClient(client sspvo.Client, path string, opts ...RecordOption) *RecordClient {
+	o := recordOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &RecordClient{
+		client: client,
+		path:   path,
+		opts:   &o,
+	}
+}
+
+//Send the message with the wrapped client and record the exchange, the response is returned unchanged.
+func (c *RecordClient) Send(ctx context.Context, msg sspvo.Message) (res sspvo.Response) {
+	recorded := &recordMessage{Message: msg}
+	res = c.client.Send(ctx, recorded)
+
+	interaction := Interaction{
+		PathMethod: msg.PathMethod(),
+		Request:    string(recorded.body),
+	}
+	if resp := res.ClientResponse(); resp != nil {
+		interaction.Code = resp.Code
+		interaction.Header = resp.Header.Clone()
+		interaction.Response = string(resp.Body)
+	}
+	if err := res.Error(); err != nil && res.ClientResponse() == nil {
+		interaction.Error = err.Error()
+	}
+	if c.opts.redact != nil {
+		c.opts.redact(&interaction)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+	return
+}
+
+//Save Write the interactions recorded so far to the cassette file.
+func (c *RecordClient) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cassette.Save(c.path)
+}
+
+//Close Write the cassette file, the client may be used and closed again, every call writes the whole cassette.
+func (c *RecordClient) Close() error {
+	return c.Save()
+}
+
+func (c *RecordClient) PrepareBody(msg sspvo.Message) ([]byte, error) {
+	return c.client.PrepareBody(msg)
+}
+
+//Cassette Receive a copy of the interactions recorded so far.
+func (c *RecordClient) Cassette() Cassette {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), c.cassette.Interactions...)}
+}
+
+//recordMessage Message wrapper that keeps the body produced for sending.
+type recordMessage struct {
+	sspvo.Message
+	body []byte
+}
+
+func (m *recordMessage) UpdateJWTFields(fields ...sspvo.Fields) sspvo.Message {
+	m.Message.UpdateJWTFields(fields...)
+	return m
+}
+
+func (m *recordMessage) GetJWT() ([]byte, error) {
+	body, err := m.Message.GetJWT()
+	m.body = body
+	return body, err
+}
+
+//GetJWTContext Sign the token within the context when the wrapped message implements sspvo.ContextMessage.
+func (m *recordMessage) GetJWTContext(ctx context.Context) ([]byte, error) {
+	contextMessage, ok := m.Message.(sspvo.ContextMessage)
+	if !ok {
+		return m.GetJWT()
+	}
+	body, err := contextMessage.GetJWTContext(ctx)
+	m.body = body
+	return body, err
+}
+
+//JWTFields Receive the fields when the wrapped message implements sspvo.FieldsMessage, otherwise nil.
+func (m *recordMessage) JWTFields() sspvo.JWTFields {
+	if fieldsMessage, ok := m.Message.(sspvo.FieldsMessage); ok {
+		return fieldsMessage.JWTFields()
+	}
+	return nil
+}
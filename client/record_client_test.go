@@ -0,0 +1,171 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func recordCassette(t *testing.T, redact func(*Interaction)) (*Cassette, string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Path", r.URL.Path)
+		if strings.Contains(string(body), "Genders") {
+			_, _ = w.Write([]byte("<Genders/>"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"Error":"not found"}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.RemoveAll(dir)
+	})
+	path := filepath.Join(dir, "cassette.json")
+
+	rest, err := NewRestyClient(resty.New(), SetOGRN("OGRN"), SetKPP("KPP"), SetAPIBase(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var opts []RecordOption
+	if redact != nil {
+		opts = append(opts, SetRedact(redact))
+	}
+	recorder := NewRecordClient(rest, path, opts...)
+
+	ctx := context.Background()
+	if data, err := recorder.Send(ctx, message.NewCLSMessage(message.CLSGenders)).Data(); err != nil || string(data) != "<Genders/>" {
+		t.Fatalf("Send() = %s, %v", data, err)
+	}
+	if _, err := recorder.Send(ctx, message.NewCLSMessage(message.CLSOlympics)).Data(); err == nil {
+		t.Fatalf("Send() must fail with 404")
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := recorder.Send(cancelled, message.NewInfoAllMessage()).Error(); err == nil {
+		t.Fatalf("Send() must fail with the cancelled context")
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Send() must not write the cassette, stat error = %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() twice error = %v", err)
+	}
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := recorder.Cassette(); len(got.Interactions) != len(cassette.Interactions) {
+		t.Fatalf("Cassette() = %+v, file %+v", got, cassette)
+	}
+	return cassette, path
+}
+
+func TestRecordClient_Send(t *testing.T) {
+	cassette, _ := recordCassette(t, func(interaction *Interaction) {
+		interaction.Request = strings.Replace(interaction.Request, "OGRN", "***", -1)
+	})
+
+	if len(cassette.Interactions) != 3 {
+		t.Fatalf("Interactions = %+v", cassette.Interactions)
+	}
+	genders := cassette.Interactions[0]
+	if genders.PathMethod != "cls/request" || genders.Code != http.StatusOK || genders.Response != "<Genders/>" ||
+		genders.Header.Get("X-Path") != "/cls/request" {
+		t.Errorf("Interactions[0] = %+v", genders)
+	}
+	if strings.Contains(genders.Request, `"OGRN":"OGRN"`) || !strings.Contains(genders.Request, `"CLS":"Genders"`) {
+		t.Errorf("Interactions[0].Request = %s, want redacted", genders.Request)
+	}
+	if cassette.Interactions[1].Code != http.StatusNotFound {
+		t.Errorf("Interactions[1] = %+v", cassette.Interactions[1])
+	}
+	if cassette.Interactions[2].Error == "" || cassette.Interactions[2].Code != 0 {
+		t.Errorf("Interactions[2] = %+v", cassette.Interactions[2])
+	}
+}
+
+func TestRedactWith(t *testing.T) {
+	interaction := &Interaction{Request: `{"OGRN":"OGRN"}`, Response: `{"IDJWT":"1"}`}
+	RedactWith(func(data []byte) []byte {
+		return bytes.Replace(data, []byte("OGRN\""), []byte("***\""), -1)
+	})(interaction)
+	if interaction.Request != `{"***":"***"}` {
+		t.Errorf("RedactWith() Request = %s", interaction.Request)
+	}
+	if interaction.Response != `{"IDJWT":"1"}` {
+		t.Errorf("RedactWith() Response = %s", interaction.Response)
+	}
+}
+
+func TestRecordMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        sspvo.Message
+		wantFields bool
+	}{
+		{name: "ok sign message", msg: message.NewCLSMessage(message.CLSGenders), wantFields: true},
+		{name: "ok plain message", msg: plainMessage{message.NewCLSMessage(message.CLSGenders)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorded := &recordMessage{Message: tt.msg}
+			var msg sspvo.Message = recorded
+			if _, ok := msg.(sspvo.ContextMessage); !ok {
+				t.Fatalf("recordMessage must implement sspvo.ContextMessage")
+			}
+			body, err := msg.(sspvo.ContextMessage).GetJWTContext(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(recorded.body) != string(body) || len(body) == 0 {
+				t.Errorf("GetJWTContext() body = %s, recorded %s", body, recorded.body)
+			}
+			fields := msg.(sspvo.FieldsMessage).JWTFields()
+			if got := fields[sspvo.FieldCLS] != nil; got != tt.wantFields {
+				t.Errorf("JWTFields() = %v, want fields %v", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+//plainMessage Message without the optional interfaces.
+type plainMessage struct {
+	msg sspvo.Message
+}
+
+func (m plainMessage) PathMethod() string { return m.msg.PathMethod() }
+func (m plainMessage) UpdateJWTFields(fields ...sspvo.Fields) sspvo.Message {
+	return m.msg.UpdateJWTFields(fields...)
+}
+func (m plainMessage) GetJWT() ([]byte, error)  { return m.msg.GetJWT() }
+func (m plainMessage) Response() sspvo.Response { return m.msg.Response() }
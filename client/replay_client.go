@@ -0,0 +1,110 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+var (
+	ErrNoInteraction = errors.New("client replay: no recorded interaction for the request")
+)
+
+//ReplayClient Client structure that implements the sspvo.Client interface serving the responses from the cassette without the network.
+type ReplayClient struct {
+	Client
+
+	match    Matcher
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	next     int
+}
+
+//NewReplayClient Creating a new ReplayClient, with nil match the interactions are served in the recorded order,
+//otherwise the first unused interaction accepted by match. Supports the same options as NewClient.
+func NewReplayClient(cassette *Cassette, match Matcher, opts ...Option) (*ReplayClient, error) {
+	client, err := NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayClient{
+		Client:   client,
+		match:    match,
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+//Send Answer the message with the recorded response.
+func (c *ReplayClient) Send(ctx context.Context, msg sspvo.Message) sspvo.Response {
+	return c.send(ctx, msg, func(ctx context.Context, body []byte) (*sspvo.ClientResponse, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("client send: %w", err)
+		}
+
+		request := Interaction{PathMethod: msg.PathMethod(), Request: string(body)}
+		recorded, ok := c.take(request)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNoInteraction, request.PathMethod)
+		}
+
+		if recorded.Error != "" {
+			return nil, fmt.Errorf("client send: %s", recorded.Error)
+		}
+		return &sspvo.ClientResponse{
+			Code:   recorded.Code,
+			Body:   []byte(recorded.Response),
+			Header: recorded.Header.Clone(),
+		}, nil
+	})
+}
+
+func (c *ReplayClient) take(request Interaction) (Interaction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.match == nil {
+		if c.next >= len(c.cassette.Interactions) {
+			return Interaction{}, false
+		}
+		recorded := c.cassette.Interactions[c.next]
+		if !MatchPathMethod(request, recorded) {
+			return Interaction{}, false
+		}
+		c.used[c.next] = true
+		c.next++
+		return recorded, true
+	}
+
+	for i, recorded := range c.cassette.Interactions {
+		if !c.used[i] && c.match(request, recorded) {
+			c.used[i] = true
+			return recorded, true
+		}
+	}
+	return Interaction{}, false
+}
+
+//Remaining Receive the number of the interactions not served yet.
+func (c *ReplayClient) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, used := range c.used {
+		if !used {
+			n++
+		}
+	}
+	return n
+}
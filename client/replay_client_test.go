@@ -0,0 +1,147 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func TestReplayClient_Send(t *testing.T) {
+	cassette, _ := recordCassette(t, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		match Matcher
+		msgs  []*message.CLSMessage
+		want  []string
+	}{
+		{
+			name: "ok in order",
+			msgs: []*message.CLSMessage{message.NewCLSMessage(message.CLSGenders), message.NewCLSMessage(message.CLSOlympics)},
+			want: []string{"<Genders/>", `{"Error":"not found"}`},
+		},
+		{
+			name:  "ok match body",
+			match: MatchBody,
+			msgs:  []*message.CLSMessage{message.NewCLSMessage(message.CLSOlympics), message.NewCLSMessage(message.CLSGenders)},
+			want:  []string{`{"Error":"not found"}`, "<Genders/>"},
+		},
+		{
+			name: "fail in order",
+			msgs: []*message.CLSMessage{message.NewCLSMessage(message.CLSGenders), message.NewCLSMessage(message.CLSGenders), message.NewCLSMessage(message.CLSGenders)},
+			want: []string{"<Genders/>", `{"Error":"not found"}`, ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay, err := NewReplayClient(cassette, tt.match, SetOGRN("OGRN"), SetKPP("KPP"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, msg := range tt.msgs {
+				res := replay.Send(ctx, msg)
+				if tt.want[i] == "" {
+					if !errors.Is(res.Error(), ErrNoInteraction) {
+						t.Errorf("Send() error = %v, want %v", res.Error(), ErrNoInteraction)
+					}
+					continue
+				}
+				if got := string(res.ClientResponse().Body); got != tt.want[i] {
+					t.Errorf("Send() = %s, want %s", got, tt.want[i])
+				}
+			}
+		})
+	}
+
+	replay, err := NewReplayClient(cassette, MatchPathMethod, SetOGRN("OGRN"), SetKPP("KPP"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = replay.Send(ctx, message.NewInfoAllMessage()).Error(); err == nil || errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Send() error = %v, want the recorded error", err)
+	}
+	if replay.Remaining() != 2 {
+		t.Errorf("Remaining() = %d, want 2", replay.Remaining())
+	}
+}
+
+func TestMatchBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  string
+		recorded string
+		want     bool
+	}{
+		{name: "ok token", request: `{"token":"h.p.s1"}`, recorded: `{"token":"h.p.s2"}`, want: true},
+		{name: "ok plain", request: `{"CLS":"Genders"}`, recorded: `{"CLS":"Genders"}`, want: true},
+		{name: "fail token", request: `{"token":"h.p1.s"}`, recorded: `{"token":"h.p2.s"}`},
+		{name: "fail plain", request: `{"CLS":"Genders"}`, recorded: `{"CLS":"Olympics"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchBody(Interaction{PathMethod: "token/new", Request: tt.request}, Interaction{PathMethod: "token/new", Request: tt.recorded})
+			if got != tt.want {
+				t.Errorf("MatchBody() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testLogger struct {
+	events []string
+	fields []map[string]interface{}
+	levels []sspvo.LogLevel
+}
+
+func (l *testLogger) Log(_ context.Context, level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	f := map[string]interface{}{}
+	for _, field := range fields {
+		f[field.Key] = field.Value
+	}
+	l.events = append(l.events, msg)
+	l.fields = append(l.fields, f)
+	l.levels = append(l.levels, level)
+}
+
+func TestReplayClient_logger(t *testing.T) {
+	cassette := &Cassette{Interactions: []Interaction{
+		{PathMethod: "cls/request", Code: http.StatusOK, Response: "<Genders/>"},
+		{PathMethod: "cls/request", Code: http.StatusNotFound},
+	}}
+	logger := &testLogger{}
+	replay, err := NewReplayClient(cassette, nil, SetOGRN("OGRN"), SetKPP("KPP"), SetLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		replay.Send(context.Background(), message.NewCLSMessage(message.CLSGenders))
+	}
+
+	wantLevels := []sspvo.LogLevel{sspvo.LogInfo, sspvo.LogWarn, sspvo.LogError}
+	if !reflect.DeepEqual(logger.levels, wantLevels) {
+		t.Fatalf("levels = %v, want %v", logger.levels, wantLevels)
+	}
+	for i, event := range logger.events {
+		if event != sspvo.EventMessageSent || logger.fields[i][sspvo.LogKeyPathMethod] != "cls/request" ||
+			logger.fields[i][sspvo.LogKeyCLS] != "Genders" || logger.fields[i][sspvo.LogKeyLatency] == nil {
+			t.Errorf("event %d = %s %v", i, event, logger.fields[i])
+		}
+	}
+	if logger.fields[0][sspvo.LogKeyCode] != http.StatusOK || logger.fields[2][sspvo.LogKeyError] == nil {
+		t.Errorf("fields = %v", logger.fields)
+	}
+}
@@ -10,10 +10,14 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/tracing"
 
 	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/label"
 )
 
 //RestyClient Client structure that implements the sspvo.Client interface using the rest client resty.Client.
@@ -23,7 +27,7 @@ type RestyClient struct {
 	rest *resty.Client
 }
 
-//NewRestyClient Creating a new RestyClient, supports the following options: SetOGRN, SetKPP, SetAPIBase and instance resty.Client.
+//NewRestyClient Creating a new RestyClient, supports the following options: SetOGRN, SetKPP, SetAPIBase, SetCrypto, SetWarn and instance resty.Client.
 func NewRestyClient(rest *resty.Client, opts ...Option) (sspvo.Client, error) {
 	client, err := NewClient(opts...)
 	if err != nil {
@@ -37,29 +41,28 @@ func NewRestyClient(rest *resty.Client, opts ...Option) (sspvo.Client, error) {
 }
 
 //Send a instance message, see message.Message, with the specified context and return an instance of the prepared response based on the interface sspvo.Response
-func (c *RestyClient) Send(ctx context.Context, msg sspvo.Message) (res sspvo.Response) {
+func (c *RestyClient) Send(ctx context.Context, msg sspvo.Message) sspvo.Response {
+	url := fmt.Sprintf("%s/%s", c.opts.apiBase, msg.PathMethod())
+	return c.send(ctx, msg, func(ctx context.Context, body []byte) (*sspvo.ClientResponse, error) {
+		postCtx, postSpan := tracing.Start(ctx, tracing.SpanPost,
+			label.String("http.method", http.MethodPost), label.String("http.url", url))
+		req := c.rest.R().
+			SetContext(postCtx).
+			SetBody(body)
+		global.TextMapPropagator().Inject(postCtx, req.Header)
 
-	res = msg.Response()
+		resp, err := req.Post(url)
+		if err != nil {
+			tracing.End(postSpan, err)
+			return nil, fmt.Errorf("client send: %w", err)
+		}
+		postSpan.SetAttributes(tracing.AttrStatusCode.Int(resp.StatusCode()))
+		tracing.End(postSpan, nil)
 
-	body, err := c.PrepareBody(msg)
-	if err != nil {
-		res.SetError(fmt.Errorf("client prepare body: %w", err))
-		return
-	}
-
-	resp, err := c.rest.R().
-		SetContext(ctx).
-		SetBody(body).
-		Post(fmt.Sprintf("%s/%s", c.opts.apiBase, msg.PathMethod()))
-
-	if err != nil {
-		res.SetError(fmt.Errorf("client send: %w", err))
-		return
-	}
-	res.SetClientResponse(&sspvo.ClientResponse{
-		Code:   resp.StatusCode(),
-		Body:   resp.Body(),
-		Header: resp.Header(),
+		return &sspvo.ClientResponse{
+			Code:   resp.StatusCode(),
+			Body:   resp.Body(),
+			Header: resp.Header(),
+		}, nil
 	})
-	return
 }
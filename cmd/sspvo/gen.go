@@ -0,0 +1,128 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/ftomza/go-sspvo/crypto/gostgen"
+)
+
+func runGen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: sspvo gen ca|cert [flags]")
+		fs.PrintDefaults()
+	}
+
+	var (
+		subject gostgen.Subject
+		curve   = fs.String("curve", gostgen.CurveCryptoProXchA.String(), "curve (parameter set) of the key")
+		days    = fs.Int("days", 365, "validity of the certificate in days")
+		caCert  = fs.String("ca-cert", "", "PEM certificate of the issuing CA (cert only)")
+		caKey   = fs.String("ca-key", "", "PEM private key of the issuing CA (cert only)")
+		certOut = fs.String("cert-out", "", "file for the PEM certificate, stdout by default")
+		keyOut  = fs.String("key-out", "", "file for the PEM private key, stdout by default")
+	)
+	fs.StringVar(&subject.Country, "c", "RU", "subject country")
+	fs.StringVar(&subject.Province, "st", "", "subject province")
+	fs.StringVar(&subject.Locality, "l", "", "subject locality")
+	fs.StringVar(&subject.StreetAddress, "street", "", "subject street address")
+	fs.StringVar(&subject.Organization, "o", "", "subject organization")
+	fs.StringVar(&subject.OrganizationalUnit, "ou", "", "subject organizational unit")
+	fs.StringVar(&subject.Title, "title", "", "subject title")
+	fs.StringVar(&subject.CommonName, "cn", "", "subject common name")
+	fs.StringVar(&subject.Surname, "sn", "", "subject surname")
+	fs.StringVar(&subject.GivenName, "gn", "", "subject given name")
+	fs.StringVar(&subject.Email, "email", "", "subject email")
+	fs.StringVar(&subject.OGRN, "ogrn", "", "subject OGRN")
+	fs.StringVar(&subject.OGRNIP, "ogrnip", "", "subject OGRNIP")
+	fs.StringVar(&subject.INN, "inn", "", "subject INN of the individual")
+	fs.StringVar(&subject.INNLE, "innle", "", "subject INN of the legal entity")
+	fs.StringVar(&subject.SNILS, "snils", "", "subject SNILS")
+
+	if len(args) < 1 {
+		fs.Usage()
+		return errors.New("gen: kind not set")
+	}
+
+	kind := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	opts := []gostgen.Option{
+		gostgen.SetCurve(gostgen.Curve(*curve)),
+		gostgen.SetSubject(subject),
+		gostgen.SetValidity(now, now.AddDate(0, 0, *days)),
+	}
+
+	var (
+		identity *gostgen.Identity
+		err      error
+	)
+	switch kind {
+	case "ca":
+		identity, err = gostgen.NewCA(opts...)
+	case "cert":
+		identity, err = issueCert(*caCert, *caKey, opts)
+	default:
+		fs.Usage()
+		return fmt.Errorf("gen: unknown kind %q", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	key, err := identity.KeyPEM()
+	if err != nil {
+		return err
+	}
+
+	if err = writeOut(*certOut, identity.CertPEM()); err != nil {
+		return err
+	}
+	return writeOut(*keyOut, key)
+}
+
+func issueCert(caCertFile, caKeyFile string, opts []gostgen.Option) (*gostgen.Identity, error) {
+	if caCertFile == "" || caKeyFile == "" {
+		return nil, errors.New("gen: ca-cert and ca-key are required")
+	}
+
+	cert, err := ioutil.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("gen: %w", err)
+	}
+
+	key, err := ioutil.ReadFile(caKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("gen: %w", err)
+	}
+
+	ca, err := gostgen.ParseIdentity(string(cert), string(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return ca.Issue(opts...)
+}
+
+func writeOut(file, data string) error {
+	if file == "" {
+		_, err := fmt.Fprint(os.Stdout, data)
+		return err
+	}
+	return ioutil.WriteFile(file, []byte(data), 0600)
+}
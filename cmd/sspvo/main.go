@@ -0,0 +1,52 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{name: "gen", usage: "generate GOST keys and certificates for test environments", run: runGen},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: sspvo <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != os.Args[1] {
+			continue
+		}
+		if err := cmd.run(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	usage()
+	os.Exit(2)
+}
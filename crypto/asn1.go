@@ -0,0 +1,121 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"time"
+)
+
+//OIDs of the attributes of the distinguished name, including the Russian-specific.
+var (
+	OIDCountry            = asn1.ObjectIdentifier{2, 5, 4, 6}
+	OIDProvince           = asn1.ObjectIdentifier{2, 5, 4, 8}
+	OIDLocality           = asn1.ObjectIdentifier{2, 5, 4, 7}
+	OIDStreetAddress      = asn1.ObjectIdentifier{2, 5, 4, 9}
+	OIDOrganization       = asn1.ObjectIdentifier{2, 5, 4, 10}
+	OIDOrganizationalUnit = asn1.ObjectIdentifier{2, 5, 4, 11}
+	OIDTitle              = asn1.ObjectIdentifier{2, 5, 4, 12}
+	OIDCommonName         = asn1.ObjectIdentifier{2, 5, 4, 3}
+	OIDSurname            = asn1.ObjectIdentifier{2, 5, 4, 4}
+	OIDGivenName          = asn1.ObjectIdentifier{2, 5, 4, 42}
+	OIDEmailAddress       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+	OIDOGRN               = asn1.ObjectIdentifier{1, 2, 643, 100, 1}
+	OIDSNILS              = asn1.ObjectIdentifier{1, 2, 643, 100, 3}
+	OIDINNLE              = asn1.ObjectIdentifier{1, 2, 643, 100, 4}
+	OIDOGRNIP             = asn1.ObjectIdentifier{1, 2, 643, 100, 5}
+	OIDINN                = asn1.ObjectIdentifier{1, 2, 643, 3, 131, 1, 1}
+)
+
+//OIDs of the GOST algorithms of the keys, the digests and the signatures.
+var (
+	OIDGost34102001                  = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 19}
+	OIDGost34102012256               = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 1}
+	OIDGost34102012512               = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 2}
+	OIDGost34112012256               = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 2}
+	OIDGost34112012512               = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 3}
+	OIDSignWithDigestGost34102001    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 3}
+	OIDSignWithDigestGost34102012256 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 3, 2}
+	OIDSignWithDigestGost34102012512 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 3, 3}
+)
+
+//OIDs of the GOST R 34.10 parameter sets.
+var (
+	OIDGostR34102001CryptoProA    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}
+	OIDGostR34102001CryptoProB    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 2}
+	OIDGostR34102001CryptoProC    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 3}
+	OIDGostR34102001CryptoProXchA = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 0}
+	OIDGostR34102001CryptoProXchB = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 1}
+	OIDGost34102012256ParamSetA   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 1}
+	OIDGost34102012256ParamSetB   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2}
+	OIDGost34102012256ParamSetC   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 3}
+	OIDGost34102012256ParamSetD   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 4}
+	OIDGost34102012512ParamSetA   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 1}
+	OIDGost34102012512ParamSetB   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 2}
+	OIDGost34102012512ParamSetC   = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 3}
+)
+
+//OIDs of the extensions of the certificate.
+var (
+	OIDExtensionKeyUsage          = asn1.ObjectIdentifier{2, 5, 29, 15}
+	OIDExtensionExtendedKeyUsage  = asn1.ObjectIdentifier{2, 5, 29, 37}
+	OIDExtensionBasicConstraints  = asn1.ObjectIdentifier{2, 5, 29, 19}
+	OIDExtensionSubjectKeyID      = asn1.ObjectIdentifier{2, 5, 29, 14}
+	OIDExtensionAuthorityKeyID    = asn1.ObjectIdentifier{2, 5, 29, 35}
+	OIDExtKeyUsageClientAuth      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}
+	OIDExtKeyUsageEmailProtection = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 4}
+)
+
+//Certificate ASN.1 structure of the x509 certificate with the GOST keys, not supported by crypto/x509.
+type Certificate struct {
+	Raw                asn1.RawContent
+	TBSCertificate     TBSCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+//TBSCertificate ASN.1 structure of the signed part of the certificate.
+type TBSCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           Validity
+	Subject            asn1.RawValue
+	PublicKeyInfo      PublicKeyInfo
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+//Validity ASN.1 structure of the validity period of the certificate.
+type Validity struct {
+	NotBefore, NotAfter time.Time
+}
+
+//PublicKeyInfo ASN.1 structure of the public key of the certificate.
+type PublicKeyInfo struct {
+	Raw       asn1.RawContent
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+//PublicKeyParams ASN.1 structure of the parameters of the GOST public key.
+type PublicKeyParams struct {
+	Curve  asn1.ObjectIdentifier
+	Digest asn1.ObjectIdentifier `asn1:"optional"`
+}
+
+//BasicConstraints ASN.1 structure of the extension of the basic constraints.
+type BasicConstraints struct {
+	IsCA       bool `asn1:"optional"`
+	MaxPathLen int  `asn1:"optional,default:-1"`
+}
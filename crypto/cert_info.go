@@ -0,0 +1,258 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	gost_crypto "github.com/ftomza/go-gost-crypto"
+)
+
+var (
+	keyAlgorithmNames = map[string]string{
+		"1.2.643.2.2.19":       "GOST R 34.10-2001",
+		"1.2.643.7.1.1.1.1":    "GOST R 34.10-2012 256",
+		"1.2.643.7.1.1.1.2":    "GOST R 34.10-2012 512",
+		"1.2.643.7.1.1.6.1":    "GOST R 34.10-2012 256 agreement",
+		"1.2.643.7.1.1.6.2":    "GOST R 34.10-2012 512 agreement",
+		"1.2.643.7.1.1.3.2":    "GOST R 34.10-2012 256 with GOST R 34.11-2012 256",
+		"1.2.643.7.1.1.3.3":    "GOST R 34.10-2012 512 with GOST R 34.11-2012 512",
+		"1.2.643.2.2.3":        "GOST R 34.10-2001 with GOST R 34.11-94",
+		"1.2.840.10045.2.1":    "ECDSA",
+		"1.2.840.113549.1.1.1": "RSA",
+	}
+
+	curveNames = map[string]string{
+		"1.2.643.2.2.35.1":    "id-GostR3410-2001-CryptoPro-A-ParamSet",
+		"1.2.643.2.2.35.2":    "id-GostR3410-2001-CryptoPro-B-ParamSet",
+		"1.2.643.2.2.35.3":    "id-GostR3410-2001-CryptoPro-C-ParamSet",
+		"1.2.643.2.2.36.0":    "id-GostR3410-2001-CryptoPro-XchA-ParamSet",
+		"1.2.643.2.2.36.1":    "id-GostR3410-2001-CryptoPro-XchB-ParamSet",
+		"1.2.643.7.1.2.1.1.1": "id-tc26-gost-3410-12-256-paramSetA",
+		"1.2.643.7.1.2.1.1.2": "id-tc26-gost-3410-12-256-paramSetB",
+		"1.2.643.7.1.2.1.1.3": "id-tc26-gost-3410-12-256-paramSetC",
+		"1.2.643.7.1.2.1.1.4": "id-tc26-gost-3410-12-256-paramSetD",
+		"1.2.643.7.1.2.1.2.1": "id-tc26-gost-3410-12-512-paramSetA",
+		"1.2.643.7.1.2.1.2.2": "id-tc26-gost-3410-12-512-paramSetB",
+		"1.2.643.7.1.2.1.2.3": "id-tc26-gost-3410-12-512-paramSetC",
+		"1.2.643.7.1.2.1.2.0": "id-tc26-gost-3410-12-512-paramSetTest",
+		"1.2.643.2.2.35.0":    "id-GostR3410-2001-TestParamSet",
+		"1.2.840.10045.3.1.7": "P-256",
+		"1.3.132.0.34":        "P-384",
+		"1.3.132.0.35":        "P-521",
+	}
+)
+
+//Name Distinguished name of the certificate with the Russian-specific attributes.
+type Name struct {
+	Country            string
+	Province           string
+	Locality           string
+	StreetAddress      string
+	Organization       string
+	OrganizationalUnit string
+	Title              string
+	CommonName         string
+	Surname            string
+	GivenName          string
+	Email              string
+	OGRN               string
+	OGRNIP             string
+	INN                string
+	INNLE              string
+	SNILS              string
+
+	//Names All attributes of the name in the order of the certificate, including unknown.
+	Names []pkix.AttributeTypeAndValue
+}
+
+func (n *Name) fields() []struct {
+	short string
+	oid   asn1.ObjectIdentifier
+	value *string
+} {
+	return []struct {
+		short string
+		oid   asn1.ObjectIdentifier
+		value *string
+	}{
+		{short: "C", oid: OIDCountry, value: &n.Country},
+		{short: "ST", oid: OIDProvince, value: &n.Province},
+		{short: "L", oid: OIDLocality, value: &n.Locality},
+		{short: "STREET", oid: OIDStreetAddress, value: &n.StreetAddress},
+		{short: "O", oid: OIDOrganization, value: &n.Organization},
+		{short: "OU", oid: OIDOrganizationalUnit, value: &n.OrganizationalUnit},
+		{short: "T", oid: OIDTitle, value: &n.Title},
+		{short: "CN", oid: OIDCommonName, value: &n.CommonName},
+		{short: "SN", oid: OIDSurname, value: &n.Surname},
+		{short: "G", oid: OIDGivenName, value: &n.GivenName},
+		{short: "E", oid: OIDEmailAddress, value: &n.Email},
+		{short: "OGRN", oid: OIDOGRN, value: &n.OGRN},
+		{short: "OGRNIP", oid: OIDOGRNIP, value: &n.OGRNIP},
+		{short: "INN", oid: OIDINN, value: &n.INN},
+		{short: "INNLE", oid: OIDINNLE, value: &n.INNLE},
+		{short: "SNILS", oid: OIDSNILS, value: &n.SNILS},
+	}
+}
+
+func (n *Name) fill(rdn pkix.RDNSequence) {
+	fields := n.fields()
+	for _, set := range rdn {
+		for _, atv := range set {
+			n.Names = append(n.Names, atv)
+			value, ok := atv.Value.(string)
+			if !ok {
+				continue
+			}
+			for _, field := range fields {
+				if field.oid.Equal(atv.Type) {
+					*field.value = value
+					break
+				}
+			}
+		}
+	}
+}
+
+//String representation of the name in the form "CN=...,O=...,OGRN=...".
+func (n Name) String() string {
+	var parts []string
+	for _, field := range n.fields() {
+		if *field.value != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s", field.short, *field.value))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+//CertInfo Description of the certificate, see ParseCertInfo.
+type CertInfo struct {
+	Subject      Name
+	Issuer       Name
+	SerialNumber *big.Int
+	NotBefore    time.Time
+	NotAfter     time.Time
+
+	//KeyAlgorithm Human readable name of the public key algorithm, or its OID if unknown.
+	KeyAlgorithm string
+	//KeyAlgorithmOID OID of the public key algorithm.
+	KeyAlgorithmOID asn1.ObjectIdentifier
+	//Curve Name of the GOST parameter set of the public key, or its OID if unknown.
+	Curve string
+	//CurveOID OID of the GOST parameter set of the public key.
+	CurveOID asn1.ObjectIdentifier
+	//SignatureAlgorithm Human readable name of the algorithm the issuer signed the certificate with.
+	SignatureAlgorithm string
+
+	KeyUsage    x509.KeyUsage
+	ExtKeyUsage []asn1.ObjectIdentifier
+	IsCA        bool
+}
+
+//IsValidAt Checking that the moment is inside the validity window of the certificate
+func (i *CertInfo) IsValidAt(t time.Time) bool {
+	return !t.Before(i.NotBefore) && !t.After(i.NotAfter)
+}
+
+//ParseCertInfo Receive the description of the certificate in the DER x509 format wrapped in PEM.
+func ParseCertInfo(cert string) (*CertInfo, error) {
+	der, err := gost_crypto.DerDecode([]byte(cert))
+	if err != nil {
+		return nil, fmt.Errorf("certInfo: %w", err)
+	}
+
+	var c Certificate
+	rest, err := asn1.Unmarshal(der.Bytes, &c)
+	if err != nil {
+		return nil, fmt.Errorf("certInfo: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("certInfo: trailing data after ASN.1 of certificate")
+	}
+
+	tbs := c.TBSCertificate
+	info := &CertInfo{
+		SerialNumber:       tbs.SerialNumber,
+		NotBefore:          tbs.Validity.NotBefore,
+		NotAfter:           tbs.Validity.NotAfter,
+		KeyAlgorithmOID:    tbs.PublicKeyInfo.Algorithm.Algorithm,
+		KeyAlgorithm:       oidName(keyAlgorithmNames, tbs.PublicKeyInfo.Algorithm.Algorithm),
+		SignatureAlgorithm: oidName(keyAlgorithmNames, c.SignatureAlgorithm.Algorithm),
+	}
+
+	for _, dn := range []struct {
+		raw  []byte
+		name *Name
+	}{
+		{raw: tbs.Subject.FullBytes, name: &info.Subject},
+		{raw: tbs.Issuer.FullBytes, name: &info.Issuer},
+	} {
+		var rdn pkix.RDNSequence
+		if _, err = asn1.Unmarshal(dn.raw, &rdn); err != nil {
+			return nil, fmt.Errorf("certInfo: %w", err)
+		}
+		dn.name.fill(rdn)
+	}
+
+	var params PublicKeyParams
+	if _, err = asn1.Unmarshal(tbs.PublicKeyInfo.Algorithm.Parameters.FullBytes, &params); err == nil {
+		info.CurveOID = params.Curve
+		info.Curve = oidName(curveNames, params.Curve)
+	} else {
+		var curve asn1.ObjectIdentifier
+		if _, err = asn1.Unmarshal(tbs.PublicKeyInfo.Algorithm.Parameters.FullBytes, &curve); err == nil {
+			info.CurveOID = curve
+			info.Curve = oidName(curveNames, curve)
+		}
+	}
+
+	for _, ext := range tbs.Extensions {
+		switch {
+		case ext.Id.Equal(OIDExtensionKeyUsage):
+			var usage asn1.BitString
+			if _, err = asn1.Unmarshal(ext.Value, &usage); err != nil {
+				return nil, fmt.Errorf("certInfo: key usage: %w", err)
+			}
+			for i := 0; i < 9; i++ {
+				if usage.At(i) != 0 {
+					info.KeyUsage |= 1 << uint(i)
+				}
+			}
+		case ext.Id.Equal(OIDExtensionExtendedKeyUsage):
+			if _, err = asn1.Unmarshal(ext.Value, &info.ExtKeyUsage); err != nil {
+				return nil, fmt.Errorf("certInfo: ext key usage: %w", err)
+			}
+		case ext.Id.Equal(OIDExtensionBasicConstraints):
+			var constraints BasicConstraints
+			if _, err = asn1.Unmarshal(ext.Value, &constraints); err != nil {
+				return nil, fmt.Errorf("certInfo: basic constraints: %w", err)
+			}
+			info.IsCA = constraints.IsCA
+		}
+	}
+
+	return info, nil
+}
+
+//CertInfo Receive the description of options cert
+func (c *Crypto) CertInfo() (*CertInfo, error) {
+	return ParseCertInfo(c.opts.cert)
+}
+
+func oidName(names map[string]string, oid asn1.ObjectIdentifier) string {
+	if name := names[oid.String()]; name != "" {
+		return name
+	}
+	return oid.String()
+}
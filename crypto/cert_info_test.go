@@ -0,0 +1,140 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto_test
+
+import (
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/crypto"
+	"github.com/ftomza/go-sspvo/crypto/gostgen"
+)
+
+func TestParseCertInfo(t *testing.T) {
+	ca, err := gostgen.NewCA(gostgen.SetSubject(gostgen.Subject{CommonName: "Test CA"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notBefore := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	leaf, err := ca.Issue(
+		gostgen.SetCurve(gostgen.CurveTC26512A),
+		gostgen.SetSerialNumber(big.NewInt(42)),
+		gostgen.SetValidity(notBefore, notBefore.AddDate(1, 0, 0)),
+		gostgen.SetSubject(gostgen.Subject{
+			Country:    "RU",
+			CommonName: "Тестовый университет",
+			OGRN:       "1027700000000",
+			INNLE:      "7700000000",
+			SNILS:      "12345678901",
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cert    string
+		check   func(t *testing.T, got *crypto.CertInfo)
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			cert: crypto.ValidCert,
+			check: func(t *testing.T, got *crypto.CertInfo) {
+				if got.Subject.CommonName != "Александр Сергеевич Пушкин" || got.Subject.Title != "Главный редактор" {
+					t.Errorf("Subject = %v", got.Subject)
+				}
+				if got.Issuer.String() != got.Subject.String() {
+					t.Errorf("Issuer = %v, want %v", got.Issuer, got.Subject)
+				}
+				if got.SerialNumber.Int64() != 1575564334 {
+					t.Errorf("SerialNumber = %v", got.SerialNumber)
+				}
+				if !got.NotBefore.Equal(time.Date(2020, 9, 22, 21, 0, 0, 0, time.UTC)) {
+					t.Errorf("NotBefore = %v", got.NotBefore)
+				}
+				if got.KeyAlgorithm != "GOST R 34.10-2012 256" || got.Curve != "id-GostR3410-2001-CryptoPro-XchA-ParamSet" {
+					t.Errorf("KeyAlgorithm = %v, Curve = %v", got.KeyAlgorithm, got.Curve)
+				}
+				if got.KeyUsage&x509.KeyUsageCertSign == 0 || got.KeyUsage&x509.KeyUsageDigitalSignature == 0 || !got.IsCA {
+					t.Errorf("KeyUsage = %v, IsCA = %v", got.KeyUsage, got.IsCA)
+				}
+				if len(got.ExtKeyUsage) != 4 {
+					t.Errorf("ExtKeyUsage = %v", got.ExtKeyUsage)
+				}
+			},
+		},
+		{
+			name: "ok russian attributes",
+			cert: leaf.CertPEM(),
+			check: func(t *testing.T, got *crypto.CertInfo) {
+				want := "C=RU,CN=Тестовый университет,OGRN=1027700000000,INNLE=7700000000,SNILS=12345678901"
+				if got.Subject.String() != want {
+					t.Errorf("Subject = %v, want %v", got.Subject, want)
+				}
+				if got.Issuer.CommonName != "Test CA" {
+					t.Errorf("Issuer = %v", got.Issuer)
+				}
+				if got.SerialNumber.Int64() != 42 || !got.NotBefore.Equal(notBefore) || !got.NotAfter.Equal(notBefore.AddDate(1, 0, 0)) {
+					t.Errorf("SerialNumber = %v, NotBefore = %v, NotAfter = %v", got.SerialNumber, got.NotBefore, got.NotAfter)
+				}
+				if got.KeyAlgorithm != "GOST R 34.10-2012 512" || got.Curve != "id-tc26-gost-3410-12-512-paramSetA" {
+					t.Errorf("KeyAlgorithm = %v, Curve = %v", got.KeyAlgorithm, got.Curve)
+				}
+				if got.SignatureAlgorithm != "GOST R 34.10-2012 256 with GOST R 34.11-2012 256" {
+					t.Errorf("SignatureAlgorithm = %v", got.SignatureAlgorithm)
+				}
+				if got.IsCA || got.KeyUsage&x509.KeyUsageCertSign != 0 || got.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+					t.Errorf("KeyUsage = %v, IsCA = %v", got.KeyUsage, got.IsCA)
+				}
+				if !got.IsValidAt(notBefore.AddDate(0, 1, 0)) || got.IsValidAt(notBefore.AddDate(2, 0, 0)) {
+					t.Errorf("IsValidAt() wrong window")
+				}
+			},
+		},
+		{
+			name:    "fail der",
+			cert:    "",
+			wantErr: true,
+		},
+		{
+			name:    "fail cert",
+			cert:    crypto.BadCertOrKey,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crypto.ParseCertInfo(tt.cert)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCertInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestCrypto_CertInfo(t *testing.T) {
+	c, err := crypto.NewCrypto(crypto.SetCert(crypto.ValidCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.CertInfo()
+	if err != nil {
+		t.Fatalf("CertInfo() error = %v", err)
+	}
+	if got.Subject.Organization != "Журнал \"Современник\"" {
+		t.Errorf("CertInfo() Subject = %v", got.Subject)
+	}
+}
@@ -0,0 +1,490 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+var (
+	oidData                   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidAttributeContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidAttributeSigningCertV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+
+	oidDigestSHA256 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+)
+
+//ErrCMSInvalidSignature The detached signature does not match the data or the signer certificate.
+var ErrCMSInvalidSignature = errors.New("cms: invalid signature")
+
+type cmsOptions struct {
+	signingTime        time.Time
+	withoutCertificate bool
+}
+
+type CMSOption func(*cmsOptions)
+
+//SetSigningTime To set the signing time attribute, by default the current time.
+func SetSigningTime(t time.Time) CMSOption {
+	return func(o *cmsOptions) {
+		o.signingTime = t
+	}
+}
+
+//SetWithoutCertificate To not embed the signer certificate into the signature, the verifier must then know the certificate.
+func SetWithoutCertificate() CMSOption {
+	return func(o *cmsOptions) {
+		o.withoutCertificate = true
+	}
+}
+
+//CMSSigner Description of the signer of the verified detached signature.
+type CMSSigner struct {
+	Cert        string
+	CertInfo    *CertInfo
+	SigningTime time.Time
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+//contentInfo The content is [0] EXPLICIT, the tag is set on the RawValue itself.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue
+}
+
+type encapsulatedContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     asn1.RawValue `asn1:"optional"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []algorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapsulatedContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type signerInfo struct {
+	Version            int
+	SID                issuerAndSerialNumber
+	DigestAlgorithm    algorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm algorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+type essCertIDv2 struct {
+	HashAlgorithm algorithmIdentifier `asn1:"optional"`
+	CertHash      []byte
+	IssuerSerial  issuerSerial `asn1:"optional"`
+}
+
+type issuerSerial struct {
+	Issuer       []asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+//SignDetached Create the CAdES-BES detached signature (CMS SignedData in DER) of data with the key of crypto,
+//the signed attributes contain the content type, the message digest, the signing time and the signing certificate v2.
+//Supports the following options: SetSigningTime, SetWithoutCertificate.
+func SignDetached(crypto sspvo.Crypto, data []byte, opts ...CMSOption) ([]byte, error) {
+	o := cmsOptions{signingTime: time.Now()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if snapshot, ok := crypto.(sspvo.CryptoSnapshot); ok {
+		crypto = snapshot.Snapshot()
+	}
+
+	cert, err := parseCMSCert(crypto.GetCert())
+	if err != nil {
+		return nil, err
+	}
+
+	digestAlgorithm, err := cmsDigestAlgorithm(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	tbs := cert.TBSCertificate
+	signingCert, err := asn1.Marshal(signingCertificateV2{Certs: []essCertIDv2{{
+		HashAlgorithm: digestAlgorithm,
+		CertHash:      cmsDigest(crypto, cert.raw),
+		IssuerSerial: issuerSerial{
+			Issuer:       []asn1.RawValue{{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true, Bytes: tbs.Issuer.FullBytes}},
+			SerialNumber: tbs.SerialNumber,
+		},
+	}}})
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+
+	attrs, err := marshalAttributes([]attributeValue{
+		{Type: oidAttributeContentType, Value: oidData},
+		{Type: oidAttributeMessageDigest, Value: cmsDigest(crypto, data)},
+		{Type: oidAttributeSigningTime, Value: o.signingTime.UTC()},
+		{Type: oidAttributeSigningCertV2, Value: asn1.RawValue{FullBytes: signingCert}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := signedAttributes(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := crypto.Sign(crypto.Hash(signed))
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+
+	signer := signerInfo{
+		Version: 1,
+		SID: issuerAndSerialNumber{
+			Issuer:       tbs.Issuer,
+			SerialNumber: tbs.SerialNumber,
+		},
+		DigestAlgorithm:    digestAlgorithm,
+		SignedAttrs:        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attrs},
+		SignatureAlgorithm: algorithmIdentifier{Algorithm: tbs.PublicKeyInfo.Algorithm.Algorithm},
+		Signature:          signature,
+	}
+
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []algorithmIdentifier{digestAlgorithm},
+		EncapContentInfo: encapsulatedContentInfo{EContentType: oidData},
+		SignerInfos:      []signerInfo{signer},
+	}
+	if !o.withoutCertificate {
+		sd.Certificates = asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: cert.raw}
+	}
+
+	content, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+
+	res, err := asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: content},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+
+	return res, nil
+}
+
+//VerifyDetached Verify the CAdES-BES/PKCS#7 detached signature (CMS SignedData in DER) of data.
+//The signer certificate is taken from the signature, or from crypto when it is not embedded,
+//the verifier is received through crypto.GetVerifyCrypto.
+func VerifyDetached(crypto sspvo.Crypto, signature, data []byte) (*CMSSigner, error) {
+	var ci contentInfo
+	if rest, err := asn1.Unmarshal(signature, &ci); err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("cms: trailing data after ASN.1 of signature")
+	}
+	if !ci.ContentType.Equal(oidSignedData) || ci.Content.Class != asn1.ClassContextSpecific || ci.Content.Tag != 0 {
+		return nil, fmt.Errorf("cms: unsupported content type %s", ci.ContentType)
+	}
+
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	if len(sd.EncapContentInfo.EContent.FullBytes) != 0 {
+		return nil, errors.New("cms: signature is not detached")
+	}
+	if len(sd.SignerInfos) != 1 {
+		return nil, fmt.Errorf("cms: expected one signer, got %d", len(sd.SignerInfos))
+	}
+	signer := sd.SignerInfos[0]
+
+	certPEM, cert, err := findCMSCert(crypto, sd.Certificates.Bytes, signer.SID)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := crypto.GetVerifyCrypto(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+
+	info, err := ParseCertInfo(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	res := &CMSSigner{Cert: certPEM, CertInfo: info}
+
+	if !isGostSignatureAlgorithm(signer.SignatureAlgorithm.Algorithm) {
+		return nil, fmt.Errorf("cms: unsupported signature algorithm %s", signer.SignatureAlgorithm.Algorithm)
+	}
+	digestAlgorithm, err := cmsDigestAlgorithm(cert)
+	if err != nil {
+		return nil, err
+	}
+	if !signer.DigestAlgorithm.Algorithm.Equal(digestAlgorithm.Algorithm) {
+		return nil, fmt.Errorf("cms: unsupported digest algorithm %s", signer.DigestAlgorithm.Algorithm)
+	}
+
+	signed := data
+	if len(signer.SignedAttrs.Bytes) != 0 {
+		signed, err = signedAttributes(signer.SignedAttrs.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if err = checkAttributes(verifier, signer.SignedAttrs.Bytes, data, cert, res); err != nil {
+			return nil, err
+		}
+	}
+
+	ok, err := verifier.Verify(signer.Signature, verifier.Hash(signed))
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	if !ok {
+		return nil, ErrCMSInvalidSignature
+	}
+
+	return res, nil
+}
+
+func checkAttributes(verifier sspvo.Crypto, attrs, data []byte, cert *cmsCert, res *CMSSigner) error {
+	var (
+		seen          = map[string]bool{}
+		contentType   asn1.ObjectIdentifier
+		messageDigest []byte
+	)
+	for rest := attrs; len(rest) != 0; {
+		var attr attribute
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &attr); err != nil {
+			return fmt.Errorf("cms: attribute: %w", err)
+		}
+		seen[attr.Type.String()] = true
+
+		switch {
+		case attr.Type.Equal(oidAttributeContentType):
+			_, err = asn1.Unmarshal(attr.Values.Bytes, &contentType)
+		case attr.Type.Equal(oidAttributeMessageDigest):
+			_, err = asn1.Unmarshal(attr.Values.Bytes, &messageDigest)
+		case attr.Type.Equal(oidAttributeSigningTime):
+			_, err = asn1.Unmarshal(attr.Values.Bytes, &res.SigningTime)
+		case attr.Type.Equal(oidAttributeSigningCertV2):
+			err = checkSigningCertificate(verifier, attr.Values.Bytes, cert)
+		}
+		if err != nil {
+			return fmt.Errorf("cms: attribute %s: %w", attr.Type, err)
+		}
+	}
+
+	if !seen[oidAttributeContentType.String()] || !seen[oidAttributeMessageDigest.String()] {
+		return errors.New("cms: content type and message digest attributes are required")
+	}
+	if !contentType.Equal(oidData) {
+		return fmt.Errorf("cms: unsupported content type %s", contentType)
+	}
+	if !bytes.Equal(messageDigest, cmsDigest(verifier, data)) {
+		return fmt.Errorf("cms: message digest: %w", ErrCMSInvalidSignature)
+	}
+
+	return nil
+}
+
+func checkSigningCertificate(verifier sspvo.Crypto, value []byte, cert *cmsCert) error {
+	var signingCert signingCertificateV2
+	if _, err := asn1.Unmarshal(value, &signingCert); err != nil {
+		return err
+	}
+	if len(signingCert.Certs) == 0 {
+		return errors.New("empty")
+	}
+
+	id := signingCert.Certs[0]
+	var certHash []byte
+	switch algorithm := id.HashAlgorithm.Algorithm; {
+	case len(algorithm) == 0 || algorithm.Equal(oidDigestSHA256):
+		sum := sha256.Sum256(cert.raw)
+		certHash = sum[:]
+	case algorithm.Equal(OIDGost34112012256) || algorithm.Equal(OIDGost34112012512):
+		certHash = cmsDigest(verifier, cert.raw)
+	default:
+		return fmt.Errorf("unsupported hash algorithm %s", algorithm)
+	}
+	if !bytes.Equal(certHash, id.CertHash) {
+		return fmt.Errorf("certificate hash: %w", ErrCMSInvalidSignature)
+	}
+	if id.IssuerSerial.SerialNumber != nil && id.IssuerSerial.SerialNumber.Cmp(cert.TBSCertificate.SerialNumber) != 0 {
+		return fmt.Errorf("certificate serial number: %w", ErrCMSInvalidSignature)
+	}
+
+	return nil
+}
+
+type cmsCert struct {
+	Certificate
+	raw []byte
+}
+
+func parseCMSCert(certPEM string) (*cmsCert, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, errors.New("cms: certificate is not in PEM format")
+	}
+	return parseCMSCertDER(block.Bytes)
+}
+
+func parseCMSCertDER(der []byte) (*cmsCert, error) {
+	cert := &cmsCert{raw: der}
+	if _, err := asn1.Unmarshal(der, &cert.Certificate); err != nil {
+		return nil, fmt.Errorf("cms: certificate: %w", err)
+	}
+	return cert, nil
+}
+
+func findCMSCert(crypto sspvo.Crypto, certs []byte, sid issuerAndSerialNumber) (string, *cmsCert, error) {
+	match := func(cert *cmsCert) bool {
+		tbs := cert.TBSCertificate
+		return tbs.SerialNumber.Cmp(sid.SerialNumber) == 0 && bytes.Equal(tbs.Issuer.FullBytes, sid.Issuer.FullBytes)
+	}
+
+	for rest := certs; len(rest) != 0; {
+		var raw asn1.RawValue
+		var err error
+		if rest, err = asn1.Unmarshal(rest, &raw); err != nil {
+			return "", nil, fmt.Errorf("cms: certificates: %w", err)
+		}
+		cert, err := parseCMSCertDER(raw.FullBytes)
+		if err != nil {
+			return "", nil, err
+		}
+		if match(cert) {
+			return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw.FullBytes})), cert, nil
+		}
+	}
+
+	if crypto.GetCert() != "" {
+		cert, err := parseCMSCert(crypto.GetCert())
+		if err != nil {
+			return "", nil, err
+		}
+		if match(cert) {
+			return crypto.GetCert(), cert, nil
+		}
+	}
+
+	return "", nil, errors.New("cms: signer certificate not found")
+}
+
+//cmsDigest GOST R 34.11-2012 of data in the byte order of CMS, the Hash of crypto returns it reversed for signing.
+func cmsDigest(crypto sspvo.Crypto, data []byte) []byte {
+	digest := crypto.Hash(data)
+	res := make([]byte, len(digest))
+	for i := range digest {
+		res[len(digest)-1-i] = digest[i]
+	}
+	return res
+}
+
+//cmsDigestAlgorithm GOST R 34.11-2012 of the size of the GOST R 34.10-2012 public key of the certificate.
+func cmsDigestAlgorithm(cert *cmsCert) (algorithmIdentifier, error) {
+	algorithm := cert.TBSCertificate.PublicKeyInfo.Algorithm.Algorithm
+	switch {
+	case algorithm.Equal(OIDGost34102012256):
+		return algorithmIdentifier{Algorithm: OIDGost34112012256}, nil
+	case algorithm.Equal(OIDGost34102012512):
+		return algorithmIdentifier{Algorithm: OIDGost34112012512}, nil
+	default:
+		return algorithmIdentifier{}, fmt.Errorf("cms: unsupported key algorithm %s", algorithm)
+	}
+}
+
+//isGostSignatureAlgorithm GOST R 34.10-2012 only, as the digest of cmsDigestAlgorithm.
+func isGostSignatureAlgorithm(oid asn1.ObjectIdentifier) bool {
+	for _, known := range []asn1.ObjectIdentifier{OIDGost34102012256, OIDGost34102012512, OIDSignWithDigestGost34102012256, OIDSignWithDigestGost34102012512} {
+		if oid.Equal(known) {
+			return true
+		}
+	}
+	return false
+}
+
+type attributeValue struct {
+	Type  asn1.ObjectIdentifier
+	Value interface{}
+}
+
+//marshalAttributes Content of DER SET OF the attributes with one value each, sorted as required by DER.
+func marshalAttributes(values []attributeValue) ([]byte, error) {
+	var encoded [][]byte
+	for _, value := range values {
+		v, err := asn1.Marshal(value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cms: attribute %s: %w", value.Type, err)
+		}
+		attr, err := asn1.Marshal(attribute{
+			Type:   value.Type,
+			Values: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: v},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cms: attribute %s: %w", value.Type, err)
+		}
+		encoded = append(encoded, attr)
+	}
+	sort.Slice(encoded, func(i, j int) bool {
+		return bytes.Compare(encoded[i], encoded[j]) < 0
+	})
+	return bytes.Join(encoded, nil), nil
+}
+
+//signedAttributes The signature is calculated over the signed attributes encoded with the SET tag.
+func signedAttributes(content []byte) ([]byte, error) {
+	res, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: content})
+	if err != nil {
+		return nil, fmt.Errorf("cms: %w", err)
+	}
+	return res, nil
+}
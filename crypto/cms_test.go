@@ -0,0 +1,369 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/crypto"
+	"github.com/ftomza/go-sspvo/crypto/gostgen"
+)
+
+func newIdentityCrypto(t *testing.T, curve gostgen.Curve) sspvo.Crypto {
+	identity, err := gostgen.NewCA(
+		gostgen.SetCurve(curve),
+		gostgen.SetSubject(gostgen.Subject{CommonName: curve.String()}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := identity.KeyPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := crypto.NewGostCrypto(crypto.SetCert(identity.CertPEM()), crypto.SetKey(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSignDetached(t *testing.T) {
+	signingTime := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	data := []byte("<PackageData>photo</PackageData>")
+
+	valid, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert), crypto.SetKey(crypto.ValidKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validVerify, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c512 := newIdentityCrypto(t, gostgen.CurveTC26512A)
+
+	tests := []struct {
+		name    string
+		crypto  sspvo.Crypto
+		opts    []crypto.CMSOption
+		verify  sspvo.Crypto
+		data    []byte
+		wantErr error
+		wantAny bool
+	}{
+		{
+			name:   "ok 256",
+			crypto: valid,
+			opts:   []crypto.CMSOption{crypto.SetSigningTime(signingTime)},
+			verify: validVerify,
+			data:   data,
+		},
+		{
+			name:   "ok 512",
+			crypto: c512,
+			opts:   []crypto.CMSOption{crypto.SetSigningTime(signingTime)},
+			verify: validVerify,
+			data:   data,
+		},
+		{
+			name:   "ok without certificate",
+			crypto: valid,
+			opts:   []crypto.CMSOption{crypto.SetSigningTime(signingTime), crypto.SetWithoutCertificate()},
+			verify: validVerify,
+			data:   data,
+		},
+		{
+			name:    "fail without certificate unknown signer",
+			crypto:  c512,
+			opts:    []crypto.CMSOption{crypto.SetSigningTime(signingTime), crypto.SetWithoutCertificate()},
+			verify:  validVerify,
+			data:    data,
+			wantAny: true,
+		},
+		{
+			name:    "fail data",
+			crypto:  valid,
+			opts:    []crypto.CMSOption{crypto.SetSigningTime(signingTime)},
+			verify:  validVerify,
+			data:    []byte("<PackageData>other</PackageData>"),
+			wantErr: crypto.ErrCMSInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := crypto.SignDetached(tt.crypto, data, tt.opts...)
+			if err != nil {
+				t.Fatalf("SignDetached() error = %v", err)
+			}
+
+			got, err := crypto.VerifyDetached(tt.verify, signature, tt.data)
+			if tt.wantErr != nil || tt.wantAny {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Errorf("VerifyDetached() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyDetached() error = %v", err)
+			}
+			info, err := crypto.ParseCertInfo(tt.crypto.GetCert())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.CertInfo.SerialNumber.Cmp(info.SerialNumber) != 0 {
+				t.Errorf("VerifyDetached() CertInfo = %v, want %v", got.CertInfo, info)
+			}
+			if !got.SigningTime.Equal(signingTime) {
+				t.Errorf("VerifyDetached() SigningTime = %v, want %v", got.SigningTime, signingTime)
+			}
+		})
+	}
+}
+
+func TestSignDetached_openssl(t *testing.T) {
+	openssl, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not found")
+	}
+	valid, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert), crypto.SetKey(crypto.ValidKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "cms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gostEngine := exec.Command(openssl, "engine", "-t", "gost").Run() == nil
+
+	tests := []struct {
+		name   string
+		crypto sspvo.Crypto
+		want   []string
+	}{
+		{
+			name:   "ok 256",
+			crypto: valid,
+			want:   []string{"1.2.643.7.1.1.2.2", "1.2.643.7.1.1.1.1"},
+		},
+		{
+			name:   "ok 512",
+			crypto: newIdentityCrypto(t, gostgen.CurveTC26512A),
+			want:   []string{"1.2.643.7.1.1.2.3", "1.2.643.7.1.1.1.2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature, err := crypto.SignDetached(tt.crypto, []byte("test"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			name := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".p7s")
+			if err = ioutil.WriteFile(name, signature, 0600); err != nil {
+				t.Fatal(err)
+			}
+			content := strings.TrimSuffix(name, ".p7s") + ".data"
+			if err = ioutil.WriteFile(content, []byte("test"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			var stderr bytes.Buffer
+			cmd := exec.Command(openssl, "cms", "-cmsout", "-print", "-inform", "DER", "-in", name)
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("openssl cms error = %v: %s", err, stderr.String())
+			}
+			want := append([]string{
+				"pkcs7-signedData (1.2.840.113549.1.7.2)",
+				"eContent: <ABSENT>",
+				"contentType (1.2.840.113549.1.9.3)",
+				"signingTime (1.2.840.113549.1.9.5)",
+				"messageDigest (1.2.840.113549.1.9.4)",
+				"(1.2.840.113549.1.9.16.2.47)",
+			}, tt.want...)
+			for _, w := range want {
+				if !bytes.Contains(out, []byte(w)) {
+					t.Errorf("openssl cms output does not contain %q:\n%s", w, out)
+				}
+			}
+
+			if !gostEngine {
+				t.Skip("openssl gost engine not found")
+			}
+			stderr.Reset()
+			cmd = exec.Command(openssl, "cms", "-verify", "-engine", "gost", "-binary", "-inform", "DER",
+				"-in", name, "-content", content, "-noverify", "-out", os.DevNull)
+			cmd.Stderr = &stderr
+			if err = cmd.Run(); err != nil {
+				t.Errorf("openssl cms -verify error = %v: %s", err, stderr.String())
+			}
+		})
+	}
+}
+
+//TestVerifyDetached_openssl Verifies the detached signatures made by openssl with the gost engine.
+func TestVerifyDetached_openssl(t *testing.T) {
+	openssl, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not found")
+	}
+	if err = exec.Command(openssl, "engine", "-t", "gost").Run(); err != nil {
+		t.Skip("openssl gost engine not found")
+	}
+	dir, err := ioutil.TempDir("", "cms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name  string
+		curve gostgen.Curve
+		md    string
+	}{
+		{name: "ok 256", curve: gostgen.CurveTC26256A, md: "md_gost12_256"},
+		{name: "ok 512", curve: gostgen.CurveTC26512A, md: "md_gost12_512"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := gostgen.NewCA(
+				gostgen.SetCurve(tt.curve),
+				gostgen.SetSubject(gostgen.Subject{CommonName: tt.curve.String()}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := identity.KeyPEM()
+			if err != nil {
+				t.Fatal(err)
+			}
+			prefix := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_"))
+			files := map[string]string{".crt": identity.CertPEM(), ".key": key, ".data": "test"}
+			for ext, body := range files {
+				if err = ioutil.WriteFile(prefix+ext, []byte(body), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var stderr bytes.Buffer
+			cmd := exec.Command(openssl, "cms", "-sign", "-engine", "gost", "-binary", "-outform", "DER",
+				"-md", tt.md, "-signer", prefix+".crt", "-inkey", prefix+".key", "-in", prefix+".data", "-out", prefix+".p7s")
+			cmd.Stderr = &stderr
+			if err = cmd.Run(); err != nil {
+				t.Fatalf("openssl cms -sign error = %v: %s", err, stderr.String())
+			}
+			signature, err := ioutil.ReadFile(prefix + ".p7s")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verify, err := crypto.NewGostCrypto(crypto.SetCert(identity.CertPEM()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = crypto.VerifyDetached(verify, signature, []byte("test")); err != nil {
+				t.Errorf("VerifyDetached() error = %v", err)
+			}
+		})
+	}
+}
+
+//TestVerifyDetached_vectors Verifies CAdES-BES signatures made by external tools, e.g.
+//openssl with the gost engine or CryptoPro cryptcp: testdata/cms/<name>.p7s is the detached
+//DER signature with the signer certificate of testdata/cms/<name>.data.
+func TestVerifyDetached_vectors(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join("testdata", "cms", "*.p7s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Skip("no external CMS vectors in testdata/cms")
+	}
+	verify, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		t.Run(filepath.Base(name), func(t *testing.T) {
+			signature, err := ioutil.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadFile(strings.TrimSuffix(name, ".p7s") + ".data")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = crypto.VerifyDetached(verify, signature, data); err != nil {
+				t.Errorf("VerifyDetached() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestSignDetached_fail(t *testing.T) {
+	verify, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = crypto.SignDetached(verify, []byte("test")); err == nil {
+		t.Errorf("SignDetached() without private key must fail")
+	}
+}
+
+func TestVerifyDetached_fail(t *testing.T) {
+	valid, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert), crypto.SetKey(crypto.ValidKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := crypto.SignDetached(valid, []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broken := append([]byte(nil), signature...)
+	broken[len(broken)-1] ^= 0xff
+
+	withoutCert, err := crypto.SignDetached(valid, []byte("test"), crypto.SetWithoutCertificate())
+	if err != nil {
+		t.Fatal(err)
+	}
+	//the signature algorithm GOST R 34.10-2012 256 is replaced by GOST R 34.10-2001 with the NULL parameters of the same length
+	algorithm2012 := []byte{0x30, 0x0a, 0x06, 0x08, 0x2a, 0x85, 0x03, 0x07, 0x01, 0x01, 0x01, 0x01}
+	algorithm2001 := []byte{0x30, 0x0a, 0x06, 0x06, 0x2a, 0x85, 0x03, 0x02, 0x02, 0x13, 0x05, 0x00}
+	if bytes.Count(withoutCert, algorithm2012) != 1 {
+		t.Fatalf("signature algorithm not found")
+	}
+	gost2001 := bytes.Replace(withoutCert, algorithm2012, algorithm2001, 1)
+
+	tests := []struct {
+		name      string
+		signature []byte
+	}{
+		{name: "fail der", signature: []byte("BAD")},
+		{name: "fail trailing", signature: append(append([]byte(nil), signature...), 0)},
+		{name: "fail signature", signature: broken},
+		{name: "fail GOST R 34.10-2001", signature: gost2001},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := crypto.VerifyDetached(valid, tt.signature, []byte("test")); err == nil {
+				t.Errorf("VerifyDetached() error = %v, wantErr true", err)
+			}
+		})
+	}
+}
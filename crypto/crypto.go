@@ -8,13 +8,18 @@
 package crypto
 
 import (
+	"context"
 	"errors"
 	"hash"
+
+	"github.com/ftomza/go-sspvo"
 )
 
 type options struct {
-	cert string
-	key  string
+	cert          string
+	key           string
+	verifierCache *VerifierCache
+	logger        sspvo.Logger
 }
 
 type Option func(*options)
@@ -33,10 +38,33 @@ func SetKey(key string) Option {
 	}
 }
 
+//SetVerifierCache To set the cache option of the verifiers built by GetVerifyCrypto, by default DefaultVerifierCache.
+func SetVerifierCache(cache *VerifierCache) Option {
+	return func(o *options) {
+		o.verifierCache = cache
+	}
+}
+
+//SetLogger To set the logger option that receives the events of signing and verification, by default nothing is logged.
+func SetLogger(logger sspvo.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+//log Write the event of the crypto if the logger is set.
+func (o *options) log(level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	if o == nil || o.logger == nil {
+		return
+	}
+	o.logger.Log(context.Background(), level, msg, fields...)
+}
+
 //Crypto Basic structure that implements the sspvo.Crypto interface.
+//A new hash.Hash is created for every call of Hash, so the structure is safe for concurrent use.
 type Crypto struct {
-	opts *options
-	hash hash.Hash
+	opts    *options
+	newHash func() hash.Hash
 }
 
 //NewCrypto Creating a new base Crypto, supports the following options: SetCert, SetKey(Optional).
@@ -62,8 +90,7 @@ func (c *Crypto) GetCert() string {
 
 //Hash Get hash(digest) of data
 func (c *Crypto) Hash(data []byte) (hash []byte) {
-	hashes := c.hash
-	hashes.Reset()
+	hashes := c.newHash()
 	_, _ = hashes.Write(data)
 	hash = hashes.Sum(nil)
 	return
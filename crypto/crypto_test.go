@@ -34,7 +34,6 @@ func TestNewCrypto(t *testing.T) {
 					cert: "CERT",
 					key:  "KEY",
 				},
-				hash: nil,
 			},
 			wantErr: false,
 		},
@@ -45,7 +44,6 @@ func TestNewCrypto(t *testing.T) {
 			},
 			want: Crypto{
 				opts: nil,
-				hash: nil,
 			},
 			wantErr: true,
 		},
@@ -65,10 +63,9 @@ func TestNewCrypto(t *testing.T) {
 }
 
 func TestCrypto_Hash(t *testing.T) {
-	hasher := md5.New()
 	type fields struct {
-		opts *options
-		hash hash.Hash
+		opts    *options
+		newHash func() hash.Hash
 	}
 	type args struct {
 		data []byte
@@ -82,7 +79,7 @@ func TestCrypto_Hash(t *testing.T) {
 		{
 			name: "ok",
 			fields: fields{
-				hash: hasher,
+				newHash: md5.New,
 			},
 			args: args{
 				data: []byte("test"),
@@ -93,8 +90,8 @@ func TestCrypto_Hash(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Crypto{
-				opts: tt.fields.opts,
-				hash: tt.fields.hash,
+				opts:    tt.fields.opts,
+				newHash: tt.fields.newHash,
 			}
 			if gotHash := c.Hash(tt.args.data); !reflect.DeepEqual(gotHash, tt.wantHash) {
 				t.Errorf("Hash() = %v, want %v", gotHash, tt.wantHash)
@@ -105,8 +102,8 @@ func TestCrypto_Hash(t *testing.T) {
 
 func TestCrypto_GetCert(t *testing.T) {
 	type fields struct {
-		opts *options
-		hash hash.Hash
+		opts    *options
+		newHash func() hash.Hash
 	}
 	tests := []struct {
 		name   string
@@ -126,8 +123,8 @@ func TestCrypto_GetCert(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Crypto{
-				opts: tt.fields.opts,
-				hash: tt.fields.hash,
+				opts:    tt.fields.opts,
+				newHash: tt.fields.newHash,
 			}
 			if got := c.GetCert(); got != tt.want {
 				t.Errorf("GetCert() = %v, want %v", got, tt.want)
@@ -0,0 +1,27 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto
+
+import "time"
+
+//Fixtures of the internal tests used by the tests of the package crypto_test,
+//they are external because crypto/gostgen depends on the package.
+var (
+	ValidCert    = validCert
+	ValidKey     = validKey
+	BadCertOrKey = badCertOrKey
+	SignExtern   = signExtern
+	ValidHash    = testHash
+)
+
+//SetNow To set the clock of the expiry checks.
+func (c *ReloadableCrypto) SetNow(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
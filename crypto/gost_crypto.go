@@ -13,21 +13,28 @@ import (
 	"errors"
 	"fmt"
 	"hash"
+	"sync"
+	"time"
 
 	gost_crypto "github.com/ftomza/go-gost-crypto"
 	"github.com/ftomza/go-sspvo"
 
 	"github.com/ftomza/gogost/gost3410"
+	"github.com/ftomza/gogost/gost34112012256"
+	"github.com/ftomza/gogost/gost34112012512"
 )
 
 //GostCrypto Crypto structure that implements the sspvo.Crypto interface using the crypto package gost3410.
+//GostCrypto is safe for concurrent use: every call of Hash, Sign and Verify works on its own hash and curve state.
 type GostCrypto struct {
 	Crypto
-	privateKey *gost3410.PrivateKey
-	publicKey  *gost3410.PublicKey
+	privateKey   *gost3410.PrivateKey
+	publicKey    *gost3410.PublicKey
+	signCurves   *curvePool
+	verifyCurves *curvePool
 }
 
-//NewGostCrypto Creating a new GostCrypto, supports the following options: SetCert, SetKey(Optional).
+//NewGostCrypto Creating a new GostCrypto, supports the following options: SetCert, SetKey(Optional), SetVerifierCache(Optional), SetLogger(Optional).
 func NewGostCrypto(opts ...Option) (sspvo.Crypto, error) {
 	crypto, err := NewCrypto(opts...)
 	if err != nil {
@@ -39,23 +46,37 @@ func NewGostCrypto(opts ...Option) (sspvo.Crypto, error) {
 		return nil, fmt.Errorf("gost_crypto: %w", err)
 	}
 
-	crypto.hash, err = parseHash(crypto.opts.cert)
+	crypto.newHash, err = parseHash(crypto.opts.cert)
 	if err != nil {
 		return nil, fmt.Errorf("gost_crypto: %w", err)
 	}
 
-	var privateKey *gost3410.PrivateKey
+	verifyCurves, err := newCurvePool(publicKey.C)
+	if err != nil {
+		return nil, fmt.Errorf("gost_crypto: %w", err)
+	}
+
+	var (
+		privateKey *gost3410.PrivateKey
+		signCurves *curvePool
+	)
 	if crypto.opts.key != "" {
 		privateKey, err = parsePrivateKey(crypto.opts.key, publicKey)
 		if err != nil {
 			return nil, fmt.Errorf("gost_crypto: %w", err)
 		}
+		signCurves, err = newCurvePool(privateKey.C)
+		if err != nil {
+			return nil, fmt.Errorf("gost_crypto: %w", err)
+		}
 	}
 
 	return &GostCrypto{
-		Crypto:     crypto,
-		privateKey: privateKey,
-		publicKey:  publicKey,
+		Crypto:       crypto,
+		privateKey:   privateKey,
+		publicKey:    publicKey,
+		signCurves:   signCurves,
+		verifyCurves: verifyCurves,
 	}, nil
 }
 
@@ -110,7 +131,7 @@ func parsePublicKey(cert string) (*gost3410.PublicKey, error) {
 	return publicKey, nil
 }
 
-func parseHash(cert string) (hash.Hash, error) {
+func parseHash(cert string) (func() hash.Hash, error) {
 	pub, err := parsePublicKeyPartOnCert(cert)
 	if err != nil {
 		return nil, fmt.Errorf("hash: %w", err)
@@ -121,12 +142,85 @@ func parseHash(cert string) (hash.Hash, error) {
 		return nil, fmt.Errorf("hash: %w", err)
 	}
 
-	return hashFunc, nil
+	switch hashFunc.Size() {
+	case gost34112012256.Size:
+		return gost34112012256.New, nil
+	case gost34112012512.Size:
+		return gost34112012512.New, nil
+	}
+	return nil, fmt.Errorf("hash: unknown size %d", hashFunc.Size())
+}
+
+//cloneCurve The curve of gost3410 keeps temporary values of the point addition inside itself,
+//so every signature operation gets its own copy to be safe for concurrent use.
+func cloneCurve(c *gost3410.Curve) (*gost3410.Curve, error) {
+	return gost3410.NewCurve(c.P, c.Q, c.A, c.B, c.X, c.Y, c.E, c.D, c.Co)
+}
+
+//curvePool Copies of the curve reused by the signature operations,
+//the parameters of the curve are validated once instead of on every operation.
+type curvePool struct {
+	pool sync.Pool
+}
+
+func newCurvePool(c *gost3410.Curve) (*curvePool, error) {
+	first, err := cloneCurve(c)
+	if err != nil {
+		return nil, err
+	}
+	p := &curvePool{}
+	p.pool.New = func() interface{} {
+		//The parameters are already validated by the first copy.
+		curve, _ := cloneCurve(c)
+		return curve
+	}
+	p.pool.Put(first)
+	return p, nil
+}
+
+//get Take the copy of the curve, without the pool the copy of c is built.
+func (p *curvePool) get(c *gost3410.Curve) (*gost3410.Curve, error) {
+	if p == nil {
+		return cloneCurve(c)
+	}
+	return p.pool.Get().(*gost3410.Curve), nil
 }
 
-//GetVerifyCrypto Get crypto instance for verify by cert
+//put Return the copy of the curve taken by get.
+func (p *curvePool) put(c *gost3410.Curve) {
+	if p != nil {
+		p.pool.Put(c)
+	}
+}
+
+//GetVerifyCrypto Get crypto instance for verify by cert, the instances are reused through the verifier cache
+//and write the events to the logger of the parent.
 func (c *GostCrypto) GetVerifyCrypto(cert string) (sspvo.Crypto, error) {
-	return NewGostCrypto(SetCert(cert))
+	var opts []Option
+	cache := defaultVerifierCache
+	if c.opts != nil && c.opts.verifierCache != nil {
+		cache = c.opts.verifierCache
+		opts = append(opts, SetVerifierCache(cache))
+	}
+	verifier, err := cache.Get(cert, func(cert string) (sspvo.Crypto, error) {
+		return NewGostCrypto(append(opts, SetCert(cert))...)
+	})
+	if err != nil || c.opts == nil || c.opts.logger == nil {
+		return verifier, err
+	}
+	if gost, ok := verifier.(*GostCrypto); ok {
+		return gost.withLogger(c.opts.logger), nil
+	}
+	return verifier, nil
+}
+
+//withLogger Copy of the crypto writing the events to the logger, the cached instance is shared by parents with the different loggers.
+func (c *GostCrypto) withLogger(logger sspvo.Logger) *GostCrypto {
+	o := *c.opts
+	o.logger = logger
+	res := *c
+	res.Crypto.opts = &o
+	return &res
 }
 
 //Hash Get a hash(digest) based on the subtleties of GOST
@@ -141,18 +235,42 @@ func (c GostCrypto) Sign(digest []byte) (sign []byte, err error) {
 	if c.privateKey == nil {
 		return nil, fmt.Errorf("gost_crypto: Private Key not set")
 	}
-	sign, err = c.privateKey.SignDigest(digest, rand.Reader)
+	curve, err := c.signCurves.get(c.privateKey.C)
+	if err != nil {
+		return nil, fmt.Errorf("gost_crypto: %w", err)
+	}
+	defer c.signCurves.put(curve)
+	privateKey := &gost3410.PrivateKey{C: curve, Key: c.privateKey.Key}
+	start := time.Now()
+	sign, err = privateKey.SignDigest(digest, rand.Reader)
 	if err != nil {
+		c.opts.log(sspvo.LogError, sspvo.EventSign, sspvo.LogField{Key: sspvo.LogKeyError, Value: err.Error()})
 		return nil, fmt.Errorf("gost_crypto: %w", err)
 	}
+	c.opts.log(sspvo.LogDebug, sspvo.EventSign, sspvo.LogField{Key: sspvo.LogKeyLatency, Value: time.Since(start)})
 	return
 }
 
 //Verify the digest signature with the public key generated at the time of initialization
 func (c GostCrypto) Verify(sign, digest []byte) (ok bool, err error) {
-	ok, err = c.publicKey.VerifyDigest(digest, sign)
+	curve, err := c.verifyCurves.get(c.publicKey.C)
 	if err != nil {
 		return false, fmt.Errorf("gost_crypto: %w", err)
 	}
+	defer c.verifyCurves.put(curve)
+	publicKey := &gost3410.PublicKey{C: curve, X: c.publicKey.X, Y: c.publicKey.Y}
+	start := time.Now()
+	ok, err = publicKey.VerifyDigest(digest, sign)
+	if err != nil {
+		c.opts.log(sspvo.LogError, sspvo.EventVerify, sspvo.LogField{Key: sspvo.LogKeyError, Value: err.Error()})
+		return false, fmt.Errorf("gost_crypto: %w", err)
+	}
+	level := sspvo.LogDebug
+	if !ok {
+		level = sspvo.LogWarn
+	}
+	c.opts.log(level, sspvo.EventVerify,
+		sspvo.LogField{Key: sspvo.LogKeyVerified, Value: ok},
+		sspvo.LogField{Key: sspvo.LogKeyLatency, Value: time.Since(start)})
 	return
 }
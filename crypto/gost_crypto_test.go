@@ -8,10 +8,13 @@
 package crypto
 
 import (
+	"context"
 	"encoding/hex"
+	"fmt"
 	"hash"
 	"math/big"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/ftomza/go-sspvo"
@@ -180,6 +183,32 @@ func Test_parsePublicKey(t *testing.T) {
 	}
 }
 
+func equalHashFunc(got, want func() hash.Hash) bool {
+	if got == nil || want == nil {
+		return got == nil && want == nil
+	}
+	gotHash, wantHash := got(), want()
+	_, _ = gotHash.Write([]byte("test"))
+	_, _ = wantHash.Write([]byte("test"))
+	return reflect.DeepEqual(gotHash.Sum(nil), wantHash.Sum(nil))
+}
+
+func equalCrypto(got, want sspvo.Crypto) bool {
+	gotGost, ok := got.(*GostCrypto)
+	wantGost, wantOk := want.(*GostCrypto)
+	if !ok || !wantOk {
+		return reflect.DeepEqual(got, want)
+	}
+	if !equalHashFunc(gotGost.newHash, wantGost.newHash) {
+		return false
+	}
+	gotCopy, wantCopy := *gotGost, *wantGost
+	gotCopy.newHash, wantCopy.newHash = nil, nil
+	gotCopy.signCurves, wantCopy.signCurves = nil, nil
+	gotCopy.verifyCurves, wantCopy.verifyCurves = nil, nil
+	return reflect.DeepEqual(gotCopy, wantCopy)
+}
+
 func Test_parseHash(t *testing.T) {
 	type args struct {
 		cert string
@@ -187,7 +216,7 @@ func Test_parseHash(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
-		want    hash.Hash
+		want    func() hash.Hash
 		wantErr bool
 	}{
 		{
@@ -195,7 +224,7 @@ func Test_parseHash(t *testing.T) {
 			args: args{
 				cert: validCert,
 			},
-			want:    gost34112012256.New(),
+			want:    gost34112012256.New,
 			wantErr: false,
 		},
 		{
@@ -230,8 +259,8 @@ func Test_parseHash(t *testing.T) {
 				t.Errorf("parseHash() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("parseHash() got = %v, want %v", got, tt.want)
+			if !equalHashFunc(got, tt.want) {
+				t.Errorf("parseHash() got = %T, want %T", got(), tt.want())
 			}
 		})
 	}
@@ -327,7 +356,7 @@ func TestNewGostCrypto(t *testing.T) {
 						cert: validCert,
 						key:  validKey,
 					},
-					hash: gost34112012256.New(),
+					newHash: gost34112012256.New,
 				},
 				privateKey: getPrivateKey(t),
 				publicKey:  getPublicKey(t),
@@ -366,7 +395,7 @@ func TestNewGostCrypto(t *testing.T) {
 				t.Errorf("NewGostCrypto() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !equalCrypto(got, tt.want) {
 				t.Errorf("NewGostCrypto() got = %v, want %v", got, tt.want)
 			}
 		})
@@ -400,7 +429,7 @@ func TestGostCrypto_GetVerifyCrypto(t *testing.T) {
 					opts: &options{
 						cert: validCert,
 					},
-					hash: gost34112012256.New(),
+					newHash: gost34112012256.New,
 				},
 				publicKey: getPublicKey(t),
 			},
@@ -419,7 +448,7 @@ func TestGostCrypto_GetVerifyCrypto(t *testing.T) {
 				t.Errorf("GetVerifyCrypto() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !equalCrypto(got, tt.want) {
 				t.Errorf("GetVerifyCrypto() got = %v, want %v", got, tt.want)
 			}
 		})
@@ -445,7 +474,7 @@ func TestGostCrypto_Hash(t *testing.T) {
 			name: "ok",
 			fields: fields{
 				Crypto: Crypto{
-					hash: gost34112012256.New(),
+					newHash: gost34112012256.New,
 				},
 			},
 			args: args{
@@ -612,3 +641,133 @@ func TestGostCrypto_Verify(t *testing.T) {
 		})
 	}
 }
+
+func TestGostCrypto_concurrent(t *testing.T) {
+	c, err := NewGostCrypto(SetCert(validCert), SetKey(validKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				data := []byte(fmt.Sprintf("test %d %d", i, j))
+				digest := c.Hash(data)
+				if !reflect.DeepEqual(digest, c.Hash(data)) {
+					errs <- fmt.Errorf("Hash() not stable for %q", data)
+					return
+				}
+				sign, err := c.Sign(digest)
+				if err != nil {
+					errs <- err
+					return
+				}
+				ok, err := c.Verify(sign, digest)
+				if err != nil || !ok {
+					errs <- fmt.Errorf("Verify() ok = %v, error = %v for %q", ok, err, data)
+					return
+				}
+				if ok, _ = c.Verify(signExtern, testHash); !ok {
+					errs <- fmt.Errorf("Verify() extern sign not verified")
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+type testLogger struct {
+	mu     sync.Mutex
+	events []string
+	levels []sspvo.LogLevel
+}
+
+func (l *testLogger) Log(_ context.Context, level sspvo.LogLevel, msg string, _ ...sspvo.LogField) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, msg)
+	l.levels = append(l.levels, level)
+}
+
+func TestGostCrypto_logger(t *testing.T) {
+	logger := &testLogger{}
+	c, err := NewGostCrypto(SetCert(validCert), SetKey(validKey), SetLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := c.Hash([]byte("test"))
+	sign, err := c.Sign(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := c.Verify(sign, digest); err != nil || !ok {
+		t.Fatalf("Verify() = %v, %v", ok, err)
+	}
+	if ok, _ := c.Verify(sign, c.Hash([]byte("other"))); ok {
+		t.Fatalf("Verify() other digest must fail")
+	}
+
+	wantEvents := []string{sspvo.EventSign, sspvo.EventVerify, sspvo.EventVerify}
+	wantLevels := []sspvo.LogLevel{sspvo.LogDebug, sspvo.LogDebug, sspvo.LogWarn}
+	if !reflect.DeepEqual(logger.events, wantEvents) || !reflect.DeepEqual(logger.levels, wantLevels) {
+		t.Errorf("events = %v %v, want %v %v", logger.events, logger.levels, wantEvents, wantLevels)
+	}
+}
+
+func TestGostCrypto_GetVerifyCrypto_logger(t *testing.T) {
+	cache := NewVerifierCache(1)
+	logger := &testLogger{}
+	c, err := NewGostCrypto(SetCert(validCert), SetKey(validKey), SetVerifierCache(cache), SetLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	silent, err := NewGostCrypto(SetCert(validCert), SetVerifierCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := c.Hash([]byte("test"))
+	sign, err := c.Sign(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.events, logger.levels = nil, nil
+
+	tests := []struct {
+		name       string
+		parent     sspvo.Crypto
+		wantEvents []string
+	}{
+		{name: "ok parent logger", parent: c, wantEvents: []string{sspvo.EventVerify}},
+		{name: "ok cached without logger", parent: silent, wantEvents: []string{sspvo.EventVerify}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := tt.parent.GetVerifyCrypto(validCert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := verifier.Verify(sign, digest); err != nil || !ok {
+				t.Fatalf("Verify() = %v, %v", ok, err)
+			}
+			if !reflect.DeepEqual(logger.events, tt.wantEvents) {
+				t.Errorf("events = %v, want %v", logger.events, tt.wantEvents)
+			}
+		})
+	}
+	if cache.Len() != 1 {
+		t.Errorf("cache Len() = %v, want 1", cache.Len())
+	}
+}
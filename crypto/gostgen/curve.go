@@ -0,0 +1,183 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package gostgen
+
+import (
+	"encoding/asn1"
+	"hash"
+
+	"github.com/ftomza/gogost/gost3410"
+	"github.com/ftomza/gogost/gost34112012256"
+	"github.com/ftomza/gogost/gost34112012512"
+
+	"github.com/ftomza/go-sspvo/crypto"
+)
+
+//Curve Enumeration of supported GOST R 34.10 curves (parameter sets).
+type Curve string
+
+const (
+	CurveCryptoProA    Curve = "cryptopro-a"
+	CurveCryptoProB    Curve = "cryptopro-b"
+	CurveCryptoProC    Curve = "cryptopro-c"
+	CurveCryptoProXchA Curve = "cryptopro-xcha"
+	CurveCryptoProXchB Curve = "cryptopro-xchb"
+	CurveTC26256A      Curve = "tc26-256-a"
+	CurveTC26256B      Curve = "tc26-256-b"
+	CurveTC26256C      Curve = "tc26-256-c"
+	CurveTC26256D      Curve = "tc26-256-d"
+	CurveTC26512A      Curve = "tc26-512-a"
+	CurveTC26512B      Curve = "tc26-512-b"
+	CurveTC26512C      Curve = "tc26-512-c"
+)
+
+var AllCurve = []Curve{
+	CurveCryptoProA,
+	CurveCryptoProB,
+	CurveCryptoProC,
+	CurveCryptoProXchA,
+	CurveCryptoProXchB,
+	CurveTC26256A,
+	CurveTC26256B,
+	CurveTC26256C,
+	CurveTC26256D,
+	CurveTC26512A,
+	CurveTC26512B,
+	CurveTC26512C,
+}
+
+//IsValid Checking the curve that it belongs to an enumeration
+func (e Curve) IsValid() bool {
+	switch e {
+	case CurveCryptoProA,
+		CurveCryptoProB,
+		CurveCryptoProC,
+		CurveCryptoProXchA,
+		CurveCryptoProXchB,
+		CurveTC26256A,
+		CurveTC26256B,
+		CurveTC26256C,
+		CurveTC26256D,
+		CurveTC26512A,
+		CurveTC26512B,
+		CurveTC26512C:
+		return true
+	}
+	return false
+}
+
+//String representation of an enumeration
+func (e Curve) String() string {
+	return string(e)
+}
+
+//Is512 Reports whether the curve belongs to the 512-bit family of GOST R 34.10-2012.
+func (e Curve) Is512() bool {
+	switch e {
+	case CurveTC26512A, CurveTC26512B, CurveTC26512C:
+		return true
+	}
+	return false
+}
+
+func (e Curve) gost() *gost3410.Curve {
+	switch e {
+	case CurveCryptoProA:
+		return gost3410.CurveIdGostR34102001CryptoProAParamSet()
+	case CurveCryptoProB:
+		return gost3410.CurveIdGostR34102001CryptoProBParamSet()
+	case CurveCryptoProC:
+		return gost3410.CurveIdGostR34102001CryptoProCParamSet()
+	case CurveCryptoProXchA:
+		return gost3410.CurveIdGostR34102001CryptoProXchAParamSet()
+	case CurveCryptoProXchB:
+		return gost3410.CurveIdGostR34102001CryptoProXchBParamSet()
+	case CurveTC26256A:
+		return gost3410.CurveIdtc26gost34102012256paramSetA()
+	case CurveTC26256B:
+		return gost3410.CurveIdtc26gost34102012256paramSetB()
+	case CurveTC26256C:
+		return gost3410.CurveIdtc26gost34102012256paramSetC()
+	case CurveTC26256D:
+		return gost3410.CurveIdtc26gost34102012256paramSetD()
+	case CurveTC26512A:
+		return gost3410.CurveIdtc26gost341012512paramSetA()
+	case CurveTC26512B:
+		return gost3410.CurveIdtc26gost341012512paramSetB()
+	case CurveTC26512C:
+		return gost3410.CurveIdtc26gost34102012512paramSetC()
+	}
+	return nil
+}
+
+func (e Curve) paramSet() asn1.ObjectIdentifier {
+	switch e {
+	case CurveCryptoProA:
+		return crypto.OIDGostR34102001CryptoProA
+	case CurveCryptoProB:
+		return crypto.OIDGostR34102001CryptoProB
+	case CurveCryptoProC:
+		return crypto.OIDGostR34102001CryptoProC
+	case CurveCryptoProXchA:
+		return crypto.OIDGostR34102001CryptoProXchA
+	case CurveCryptoProXchB:
+		return crypto.OIDGostR34102001CryptoProXchB
+	case CurveTC26256A:
+		return crypto.OIDGost34102012256ParamSetA
+	case CurveTC26256B:
+		return crypto.OIDGost34102012256ParamSetB
+	case CurveTC26256C:
+		return crypto.OIDGost34102012256ParamSetC
+	case CurveTC26256D:
+		return crypto.OIDGost34102012256ParamSetD
+	case CurveTC26512A:
+		return crypto.OIDGost34102012512ParamSetA
+	case CurveTC26512B:
+		return crypto.OIDGost34102012512ParamSetB
+	case CurveTC26512C:
+		return crypto.OIDGost34102012512ParamSetC
+	}
+	return nil
+}
+
+func (e Curve) keyAlgorithm() asn1.ObjectIdentifier {
+	if e.Is512() {
+		return crypto.OIDGost34102012512
+	}
+	return crypto.OIDGost34102012256
+}
+
+func (e Curve) digestAlgorithm() asn1.ObjectIdentifier {
+	if e.Is512() {
+		return crypto.OIDGost34112012512
+	}
+	return crypto.OIDGost34112012256
+}
+
+func (e Curve) signatureAlgorithm() asn1.ObjectIdentifier {
+	if e.Is512() {
+		return crypto.OIDSignWithDigestGost34102012512
+	}
+	return crypto.OIDSignWithDigestGost34102012256
+}
+
+func (e Curve) newHash() hash.Hash {
+	if e.Is512() {
+		return gost34112012512.New()
+	}
+	return gost34112012256.New()
+}
+
+func curveByParamSet(oid asn1.ObjectIdentifier) (Curve, bool) {
+	for _, curve := range AllCurve {
+		if curve.paramSet().Equal(oid) {
+			return curve, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,542 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+//Package gostgen generates GOST R 34.10 key pairs and X.509 certificates for test environments.
+package gostgen
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	gost_crypto "github.com/ftomza/go-gost-crypto"
+	"github.com/ftomza/gogost/gost3410"
+
+	"github.com/ftomza/go-sspvo/crypto"
+)
+
+var (
+	serialNumberLimit                 = new(big.Int).Lsh(big.NewInt(1), 63)
+	defaultValidity                   = 365 * 24 * time.Hour
+	defaultCurve                      = CurveCryptoProXchA
+	errIdentityWithoutKey             = errors.New("gostgen: identity has no private key")
+	errCertificateSignatureNotMatched = errors.New("gostgen: certificate signature not matched")
+)
+
+type options struct {
+	curve        Curve
+	subject      Subject
+	notBefore    time.Time
+	notAfter     time.Time
+	serialNumber *big.Int
+	rand         io.Reader
+}
+
+type Option func(*options)
+
+//SetCurve To set the curve option. Assigns the GOST parameter set of the generated key, by default CurveCryptoProXchA.
+func SetCurve(curve Curve) Option {
+	return func(o *options) {
+		o.curve = curve
+	}
+}
+
+//SetSubject To set the subject option. Assigns the distinguished name of the certificate.
+func SetSubject(subject Subject) Option {
+	return func(o *options) {
+		o.subject = subject
+	}
+}
+
+//SetValidity To set the validity option. Assigns the validity window of the certificate, by default one year from now.
+func SetValidity(notBefore, notAfter time.Time) Option {
+	return func(o *options) {
+		o.notBefore = notBefore
+		o.notAfter = notAfter
+	}
+}
+
+//SetSerialNumber To set the serialNumber option. By default a random serial number is used.
+func SetSerialNumber(serialNumber *big.Int) Option {
+	return func(o *options) {
+		o.serialNumber = serialNumber
+	}
+}
+
+//SetRand To set the rand option. Assigns the source of entropy, by default crypto/rand.Reader.
+func SetRand(rand io.Reader) Option {
+	return func(o *options) {
+		o.rand = rand
+	}
+}
+
+func newOptions(opts []Option) (*options, error) {
+	o := options{
+		curve: defaultCurve,
+		rand:  rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !o.curve.IsValid() {
+		return nil, fmt.Errorf("gostgen: unknown curve %q", o.curve)
+	}
+
+	if err := o.subject.validate(); err != nil {
+		return nil, err
+	}
+
+	if o.notBefore.IsZero() {
+		o.notBefore = time.Now().Add(-time.Minute)
+	}
+	if o.notAfter.IsZero() {
+		o.notAfter = o.notBefore.Add(defaultValidity)
+	}
+	if !o.notAfter.After(o.notBefore) {
+		return nil, errors.New("gostgen: notAfter must be after notBefore")
+	}
+
+	if o.serialNumber == nil {
+		serialNumber, err := rand.Int(o.rand, serialNumberLimit)
+		if err != nil {
+			return nil, fmt.Errorf("gostgen: serial number: %w", err)
+		}
+		o.serialNumber = serialNumber
+	}
+
+	return &o, nil
+}
+
+//Identity Key pair with the certificate issued for it.
+type Identity struct {
+	Curve      Curve
+	PrivateKey *gost3410.PrivateKey
+	Cert       []byte
+
+	subject []byte
+	keyID   []byte
+}
+
+//CertPEM Certificate in the PEM format, suitable for crypto.SetCert.
+func (i *Identity) CertPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: i.Cert}))
+}
+
+//KeyPEM Private key in the PEM PKCS8 format, suitable for crypto.SetKey.
+func (i *Identity) KeyPEM() (string, error) {
+	if i.PrivateKey == nil {
+		return "", errIdentityWithoutKey
+	}
+	der, err := MarshalPKCS8PrivateKey(i.Curve, i.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+//NewCA Creating a new self-signed CA Identity, supports the following options: SetCurve, SetSubject, SetValidity, SetSerialNumber, SetRand.
+func NewCA(opts ...Option) (*Identity, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := GenerateKey(o.curve, o.rand)
+	if err != nil {
+		return nil, err
+	}
+
+	return issue(o, key, nil, true)
+}
+
+//Issue Creating a new leaf Identity signed by this CA, supports the same options as NewCA.
+func (i *Identity) Issue(opts ...Option) (*Identity, error) {
+	if i.PrivateKey == nil {
+		return nil, errIdentityWithoutKey
+	}
+
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := GenerateKey(o.curve, o.rand)
+	if err != nil {
+		return nil, err
+	}
+
+	return issue(o, key, i, false)
+}
+
+//CheckSignatureFrom Verify that the certificate of the identity was signed by the parent.
+func (i *Identity) CheckSignatureFrom(parent *Identity) error {
+	var cert crypto.Certificate
+	if _, err := asn1.Unmarshal(i.Cert, &cert); err != nil {
+		return fmt.Errorf("gostgen: %w", err)
+	}
+
+	if !bytes.Equal(cert.TBSCertificate.Issuer.FullBytes, parent.subject) {
+		return errors.New("gostgen: issuer not matched with parent subject")
+	}
+
+	pub, err := parsePublicKey(parent.Cert)
+	if err != nil {
+		return err
+	}
+
+	ok, err := pub.VerifyDigest(digest(parent.Curve, cert.TBSCertificate.Raw), cert.SignatureValue.RightAlign())
+	if err != nil {
+		return fmt.Errorf("gostgen: %w", err)
+	}
+	if !ok {
+		return errCertificateSignatureNotMatched
+	}
+	return nil
+}
+
+//ParseIdentity Restore Identity from the PEM certificate and the PEM PKCS8 private key, the key is optional.
+func ParseIdentity(certPEM, keyPEM string) (*Identity, error) {
+	block, err := gost_crypto.DerDecode([]byte(certPEM))
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: cert: %w", err)
+	}
+
+	var cert crypto.Certificate
+	if _, err = asn1.Unmarshal(block.Bytes, &cert); err != nil {
+		return nil, fmt.Errorf("gostgen: cert: %w", err)
+	}
+
+	var params crypto.PublicKeyParams
+	if _, err = asn1.Unmarshal(cert.TBSCertificate.PublicKeyInfo.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("gostgen: cert: %w", err)
+	}
+
+	curve, ok := curveByParamSet(params.Curve)
+	if !ok {
+		return nil, fmt.Errorf("gostgen: cert: unknown curve %v", params.Curve)
+	}
+
+	identity := &Identity{
+		Curve:   curve,
+		Cert:    block.Bytes,
+		subject: cert.TBSCertificate.Subject.FullBytes,
+		keyID:   keyID(cert.TBSCertificate.PublicKeyInfo.PublicKey.RightAlign()),
+	}
+
+	if keyPEM == "" {
+		return identity, nil
+	}
+
+	block, err = gost_crypto.DerDecode([]byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: key: %w", err)
+	}
+
+	identity.PrivateKey, err = gost_crypto.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: key: %w", err)
+	}
+
+	pub, err := identity.PrivateKey.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: key: %w", err)
+	}
+
+	certPub, err := parsePublicKey(identity.Cert)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(pub.Raw(), certPub.Raw()) {
+		return nil, errors.New("gostgen: key: PublicKey not equal")
+	}
+
+	return identity, nil
+}
+
+//GenerateKey Generate a new private key on the curve.
+func GenerateKey(curve Curve, rand io.Reader) (*gost3410.PrivateKey, error) {
+	if !curve.IsValid() {
+		return nil, fmt.Errorf("gostgen: unknown curve %q", curve)
+	}
+	key, err := gost3410.GenPrivateKey(curve.gost(), rand)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	return key, nil
+}
+
+//MarshalPKCS8PrivateKey Converts a private key to the DER PKCS8 form.
+func MarshalPKCS8PrivateKey(curve Curve, key *gost3410.PrivateKey) ([]byte, error) {
+	algo, err := algorithmIdentifier(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := asn1.Marshal(key.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+
+	der, err := asn1.Marshal(pkcs8{
+		Version:    0,
+		Algo:       algo,
+		PrivateKey: raw,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	return der, nil
+}
+
+//MarshalPKIXPublicKey Converts a public key to the DER PKIX form.
+func MarshalPKIXPublicKey(curve Curve, pub *gost3410.PublicKey) ([]byte, error) {
+	info, err := publicKeyInfoOf(curve, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	return der, nil
+}
+
+func issue(o *options, key *gost3410.PrivateKey, parent *Identity, isCA bool) (*Identity, error) {
+	pub, err := key.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+
+	pubInfo, err := publicKeyInfoOf(o.curve, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := o.subject.marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	ski := keyID(pubInfo.PublicKey.Bytes)
+
+	signer := &Identity{
+		Curve:      o.curve,
+		PrivateKey: key,
+		subject:    subject,
+		keyID:      ski,
+	}
+	if parent != nil {
+		signer = parent
+	}
+
+	extensions, err := buildExtensions(isCA, ski, signer.keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	tbs := crypto.TBSCertificate{
+		Version:            2,
+		SerialNumber:       o.serialNumber,
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: signer.Curve.signatureAlgorithm()},
+		Issuer:             asn1.RawValue{FullBytes: signer.subject},
+		Validity:           crypto.Validity{NotBefore: o.notBefore.UTC(), NotAfter: o.notAfter.UTC()},
+		Subject:            asn1.RawValue{FullBytes: subject},
+		PublicKeyInfo:      pubInfo,
+		Extensions:         extensions,
+	}
+
+	tbs.Raw, err = asn1.Marshal(tbs)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+
+	sign, err := signer.PrivateKey.SignDigest(digest(signer.Curve, tbs.Raw), o.rand)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+
+	cert, err := asn1.Marshal(crypto.Certificate{
+		TBSCertificate:     tbs,
+		SignatureAlgorithm: tbs.SignatureAlgorithm,
+		SignatureValue:     asn1.BitString{Bytes: sign, BitLength: len(sign) * 8},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+
+	return &Identity{
+		Curve:      o.curve,
+		PrivateKey: key,
+		Cert:       cert,
+		subject:    subject,
+		keyID:      ski,
+	}, nil
+}
+
+func buildExtensions(isCA bool, ski, aki []byte) (res []pkix.Extension, err error) {
+	usage := x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment |
+		x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageKeyAgreement
+	if isCA {
+		usage = x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	ext := pkix.Extension{Id: crypto.OIDExtensionKeyUsage, Critical: true}
+	if ext.Value, err = asn1.Marshal(keyUsageBits(usage)); err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	res = append(res, ext)
+
+	if !isCA {
+		ext = pkix.Extension{Id: crypto.OIDExtensionExtendedKeyUsage}
+		ext.Value, err = asn1.Marshal([]asn1.ObjectIdentifier{crypto.OIDExtKeyUsageClientAuth, crypto.OIDExtKeyUsageEmailProtection})
+		if err != nil {
+			return nil, fmt.Errorf("gostgen: %w", err)
+		}
+		res = append(res, ext)
+	}
+
+	ext = pkix.Extension{Id: crypto.OIDExtensionBasicConstraints, Critical: true}
+	if ext.Value, err = asn1.Marshal(crypto.BasicConstraints{IsCA: isCA, MaxPathLen: -1}); err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	res = append(res, ext)
+
+	ext = pkix.Extension{Id: crypto.OIDExtensionSubjectKeyID}
+	if ext.Value, err = asn1.Marshal(ski); err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	res = append(res, ext)
+
+	ext = pkix.Extension{Id: crypto.OIDExtensionAuthorityKeyID}
+	if ext.Value, err = asn1.Marshal(authKeyId{Id: aki}); err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	res = append(res, ext)
+
+	return res, nil
+}
+
+func keyUsageBits(usage x509.KeyUsage) asn1.BitString {
+	var a [2]byte
+	a[0] = reverseBitsInAByte(byte(usage))
+	a[1] = reverseBitsInAByte(byte(usage >> 8))
+
+	l := 1
+	if a[1] != 0 {
+		l = 2
+	}
+
+	bitString := a[:l]
+	return asn1.BitString{Bytes: bitString, BitLength: asn1BitLength(bitString)}
+}
+
+func reverseBitsInAByte(in byte) byte {
+	b1 := in>>4 | in<<4
+	b2 := b1>>2&0x33 | b1<<2&0xcc
+	return b2>>1&0x55 | b2<<1&0xaa
+}
+
+func asn1BitLength(bitString []byte) int {
+	bitLen := len(bitString) * 8
+
+	for i := range bitString {
+		b := bitString[len(bitString)-i-1]
+
+		for bit := uint(0); bit < 8; bit++ {
+			if (b>>bit)&1 == 1 {
+				return bitLen
+			}
+			bitLen--
+		}
+	}
+
+	return 0
+}
+
+func algorithmIdentifier(curve Curve) (pkix.AlgorithmIdentifier, error) {
+	if !curve.IsValid() {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf("gostgen: unknown curve %q", curve)
+	}
+
+	params, err := asn1.Marshal(crypto.PublicKeyParams{
+		Curve:  curve.paramSet(),
+		Digest: curve.digestAlgorithm(),
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf("gostgen: %w", err)
+	}
+
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  curve.keyAlgorithm(),
+		Parameters: asn1.RawValue{FullBytes: params},
+	}, nil
+}
+
+func publicKeyInfoOf(curve Curve, pub *gost3410.PublicKey) (crypto.PublicKeyInfo, error) {
+	algo, err := algorithmIdentifier(curve)
+	if err != nil {
+		return crypto.PublicKeyInfo{}, err
+	}
+
+	raw, err := asn1.Marshal(pub.Raw())
+	if err != nil {
+		return crypto.PublicKeyInfo{}, fmt.Errorf("gostgen: %w", err)
+	}
+
+	return crypto.PublicKeyInfo{
+		Algorithm: algo,
+		PublicKey: asn1.BitString{Bytes: raw, BitLength: len(raw) * 8},
+	}, nil
+}
+
+func parsePublicKey(certDer []byte) (*gost3410.PublicKey, error) {
+	pubDer, err := gost_crypto.ParseCertificate(certDer)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+
+	pub, err := gost_crypto.ParsePKIXPublicKey(pubDer)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: %w", err)
+	}
+	return pub, nil
+}
+
+func digest(curve Curve, data []byte) []byte {
+	h := curve.newHash()
+	_, _ = h.Write(data)
+	sum := h.Sum(nil)
+	gost_crypto.Reverse(sum)
+	return sum
+}
+
+func keyID(pub []byte) []byte {
+	sum := sha1.Sum(pub)
+	return sum[:]
+}
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type authKeyId struct {
+	Id []byte `asn1:"optional,tag:0"`
+}
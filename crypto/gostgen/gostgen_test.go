@@ -0,0 +1,248 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package gostgen
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/crypto"
+)
+
+var testSubject = Subject{
+	Country:      "RU",
+	Organization: "ФГБОУ ВО \"Тестовый университет\"",
+	CommonName:   "Тестовый университет",
+	OGRN:         "1027700000000",
+	INNLE:        "7700000000",
+}
+
+func TestNewCA(t *testing.T) {
+	for _, curve := range AllCurve {
+		t.Run(curve.String(), func(t *testing.T) {
+			ca, err := NewCA(SetCurve(curve), SetSubject(testSubject))
+			if err != nil {
+				t.Fatalf("NewCA() error = %v", err)
+			}
+
+			if err = ca.CheckSignatureFrom(ca); err != nil {
+				t.Errorf("CheckSignatureFrom() error = %v", err)
+			}
+
+			key, err := ca.KeyPEM()
+			if err != nil {
+				t.Fatalf("KeyPEM() error = %v", err)
+			}
+
+			c, err := crypto.NewGostCrypto(crypto.SetCert(ca.CertPEM()), crypto.SetKey(key))
+			if err != nil {
+				t.Fatalf("NewGostCrypto() error = %v", err)
+			}
+
+			digest := c.Hash([]byte("test"))
+			sign, err := c.Sign(digest)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			if ok, err := c.Verify(sign, digest); err != nil || !ok {
+				t.Errorf("Verify() ok = %v, error = %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestNewCA_Options(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			opts: []Option{SetSerialNumber(big.NewInt(42)), SetValidity(now, now.Add(time.Hour))},
+		},
+		{
+			name:    "fail curve",
+			opts:    []Option{SetCurve("BAD")},
+			wantErr: true,
+		},
+		{
+			name:    "fail validity",
+			opts:    []Option{SetValidity(now, now.Add(-time.Hour))},
+			wantErr: true,
+		},
+		{
+			name:    "fail OGRN",
+			opts:    []Option{SetSubject(Subject{OGRN: "123"})},
+			wantErr: true,
+		},
+		{
+			name:    "fail SNILS",
+			opts:    []Option{SetSubject(Subject{SNILS: "1234567890A"})},
+			wantErr: true,
+		},
+		{
+			name:    "fail country",
+			opts:    []Option{SetSubject(Subject{Country: "RUS"})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCA(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCA() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIdentity_Issue(t *testing.T) {
+	ca, err := NewCA(SetSubject(Subject{CommonName: "Test CA"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCA, err := NewCA(SetCurve(CurveTC26512A), SetSubject(Subject{CommonName: "Other CA"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ca      *Identity
+		opts    []Option
+		wantErr bool
+	}{
+		{
+			name: "ok 256",
+			ca:   ca,
+			opts: []Option{SetSubject(testSubject)},
+		},
+		{
+			name: "ok 512 by 256",
+			ca:   ca,
+			opts: []Option{SetCurve(CurveTC26512B), SetSubject(Subject{SNILS: "12345678901", INN: "123456789012"})},
+		},
+		{
+			name: "ok 256 by 512",
+			ca:   otherCA,
+			opts: []Option{SetCurve(CurveTC26256A), SetSubject(Subject{OGRNIP: "312345678901234"})},
+		},
+		{
+			name:    "fail without key",
+			ca:      &Identity{Curve: ca.Curve, Cert: ca.Cert},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ca.Issue(tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Issue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if err = got.CheckSignatureFrom(tt.ca); err != nil {
+				t.Errorf("CheckSignatureFrom() error = %v", err)
+			}
+			if err = got.CheckSignatureFrom(got); err == nil {
+				t.Errorf("CheckSignatureFrom() self signed leaf must fail")
+			}
+		})
+	}
+}
+
+func TestParseIdentity(t *testing.T) {
+	ca, err := NewCA(SetCurve(CurveCryptoProA), SetSubject(testSubject))
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewCA(SetCurve(CurveCryptoProA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := ca.KeyPEM()
+	otherKey, _ := other.KeyPEM()
+
+	tests := []struct {
+		name    string
+		cert    string
+		key     string
+		want    *Identity
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			cert: ca.CertPEM(),
+			key:  key,
+			want: ca,
+		},
+		{
+			name: "ok without key",
+			cert: ca.CertPEM(),
+			want: &Identity{Curve: ca.Curve, Cert: ca.Cert, subject: ca.subject, keyID: ca.keyID},
+		},
+		{
+			name:    "fail cert",
+			cert:    "BAD",
+			wantErr: true,
+		},
+		{
+			name:    "fail key",
+			cert:    ca.CertPEM(),
+			key:     "BAD",
+			wantErr: true,
+		},
+		{
+			name:    "fail key not equal",
+			cert:    ca.CertPEM(),
+			key:     otherKey,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIdentity(tt.cert, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIdentity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !equalIdentity(got, tt.want) {
+				t.Errorf("ParseIdentity() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentity_KeyPEM(t *testing.T) {
+	_, err := (&Identity{}).KeyPEM()
+	if err == nil {
+		t.Errorf("KeyPEM() without private key must fail")
+	}
+}
+
+//equalIdentity The curve of the key keeps temporary values of the last operation, so only the key itself is compared.
+func equalIdentity(got, want *Identity) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	if (got.PrivateKey == nil) != (want.PrivateKey == nil) {
+		return false
+	}
+	if got.PrivateKey != nil &&
+		(got.PrivateKey.Key.Cmp(want.PrivateKey.Key) != 0 || got.PrivateKey.C.Name != want.PrivateKey.C.Name) {
+		return false
+	}
+	gotCopy, wantCopy := *got, *want
+	gotCopy.PrivateKey, wantCopy.PrivateKey = nil, nil
+	return reflect.DeepEqual(gotCopy, wantCopy)
+}
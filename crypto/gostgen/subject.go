@@ -0,0 +1,120 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package gostgen
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/ftomza/go-sspvo/crypto"
+)
+
+//Subject Distinguished name of the certificate with the Russian-specific attributes.
+type Subject struct {
+	Country            string
+	Province           string
+	Locality           string
+	StreetAddress      string
+	Organization       string
+	OrganizationalUnit string
+	Title              string
+	CommonName         string
+	Surname            string
+	GivenName          string
+	Email              string
+	OGRN               string
+	OGRNIP             string
+	INN                string
+	INNLE              string
+	SNILS              string
+}
+
+type attribute struct {
+	oid   asn1.ObjectIdentifier
+	tag   int
+	value string
+}
+
+func (s Subject) attributes() []attribute {
+	return []attribute{
+		{oid: crypto.OIDCountry, tag: asn1.TagPrintableString, value: s.Country},
+		{oid: crypto.OIDProvince, tag: asn1.TagUTF8String, value: s.Province},
+		{oid: crypto.OIDLocality, tag: asn1.TagUTF8String, value: s.Locality},
+		{oid: crypto.OIDStreetAddress, tag: asn1.TagUTF8String, value: s.StreetAddress},
+		{oid: crypto.OIDOrganization, tag: asn1.TagUTF8String, value: s.Organization},
+		{oid: crypto.OIDOrganizationalUnit, tag: asn1.TagUTF8String, value: s.OrganizationalUnit},
+		{oid: crypto.OIDTitle, tag: asn1.TagUTF8String, value: s.Title},
+		{oid: crypto.OIDCommonName, tag: asn1.TagUTF8String, value: s.CommonName},
+		{oid: crypto.OIDSurname, tag: asn1.TagUTF8String, value: s.Surname},
+		{oid: crypto.OIDGivenName, tag: asn1.TagUTF8String, value: s.GivenName},
+		{oid: crypto.OIDEmailAddress, tag: asn1.TagIA5String, value: s.Email},
+		{oid: crypto.OIDOGRN, tag: asn1.TagNumericString, value: s.OGRN},
+		{oid: crypto.OIDOGRNIP, tag: asn1.TagNumericString, value: s.OGRNIP},
+		{oid: crypto.OIDINN, tag: asn1.TagNumericString, value: s.INN},
+		{oid: crypto.OIDINNLE, tag: asn1.TagNumericString, value: s.INNLE},
+		{oid: crypto.OIDSNILS, tag: asn1.TagNumericString, value: s.SNILS},
+	}
+}
+
+func (s Subject) validate() error {
+	checks := []struct {
+		name  string
+		value string
+		size  int
+	}{
+		{name: "OGRN", value: s.OGRN, size: 13},
+		{name: "OGRNIP", value: s.OGRNIP, size: 15},
+		{name: "INN", value: s.INN, size: 12},
+		{name: "INNLE", value: s.INNLE, size: 10},
+		{name: "SNILS", value: s.SNILS, size: 11},
+	}
+
+	for _, check := range checks {
+		if check.value == "" {
+			continue
+		}
+		if len(check.value) != check.size || !isDigits(check.value) {
+			return fmt.Errorf("gostgen: subject %s must be %d digits, got %q", check.name, check.size, check.value)
+		}
+	}
+
+	if s.Country != "" && len(s.Country) != 2 {
+		return fmt.Errorf("gostgen: subject Country must be a two-letter code, got %q", s.Country)
+	}
+
+	return nil
+}
+
+func (s Subject) marshal() ([]byte, error) {
+	var rdn pkix.RDNSequence
+	for _, attr := range s.attributes() {
+		if attr.value == "" {
+			continue
+		}
+		rdn = append(rdn, pkix.RelativeDistinguishedNameSET{{
+			Type:  attr.oid,
+			Value: asn1.RawValue{Tag: attr.tag, Bytes: []byte(attr.value)},
+		}})
+	}
+
+	der, err := asn1.Marshal(rdn)
+	if err != nil {
+		return nil, fmt.Errorf("gostgen: subject: %w", err)
+	}
+	return der, nil
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
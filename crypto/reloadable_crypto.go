@@ -0,0 +1,325 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+var (
+	defaultReloadInterval   = time.Minute
+	defaultExpiryInterval   = time.Hour
+	defaultExpiryThresholds = []int{30, 14, 7, 3, 1}
+)
+
+type reloadOptions struct {
+	interval       time.Duration
+	expiryInterval time.Duration
+	thresholds     []int
+	onExpiry       func(info *CertInfo, daysLeft int)
+	onReload       func(info *CertInfo)
+	onError        func(err error)
+	factory        func(opts ...Option) (sspvo.Crypto, error)
+}
+
+type ReloadOption func(*reloadOptions)
+
+//SetReloadInterval To set the interval option of checking the files for changes, by default one minute, zero disables watching.
+func SetReloadInterval(interval time.Duration) ReloadOption {
+	return func(o *reloadOptions) {
+		o.interval = interval
+	}
+}
+
+//SetExpiryInterval To set the interval option of checking the certificate in use for expiry, by default one hour,
+//zero disables the checks between the reloads. The checks do not depend on the changes of the files.
+func SetExpiryInterval(interval time.Duration) ReloadOption {
+	return func(o *reloadOptions) {
+		o.expiryInterval = interval
+	}
+}
+
+//SetExpiryThresholds To set the thresholds option, days to expiry at which the expiry callback fires, by default 30, 14, 7, 3, 1.
+func SetExpiryThresholds(days ...int) ReloadOption {
+	return func(o *reloadOptions) {
+		o.thresholds = days
+	}
+}
+
+//SetOnExpiry To set the callback called once per threshold when the certificate is about to expire or has expired.
+func SetOnExpiry(onExpiry func(info *CertInfo, daysLeft int)) ReloadOption {
+	return func(o *reloadOptions) {
+		o.onExpiry = onExpiry
+	}
+}
+
+//SetOnReload To set the callback called after the new certificate and key have been swapped in.
+func SetOnReload(onReload func(info *CertInfo)) ReloadOption {
+	return func(o *reloadOptions) {
+		o.onReload = onReload
+	}
+}
+
+//SetOnReloadError To set the callback called when the changed files can not be loaded, the previous key stays in use.
+func SetOnReloadError(onError func(err error)) ReloadOption {
+	return func(o *reloadOptions) {
+		o.onError = onError
+	}
+}
+
+//SetCryptoFactory To set the factory option used to build the underlying crypto, by default NewGostCrypto.
+func SetCryptoFactory(factory func(opts ...Option) (sspvo.Crypto, error)) ReloadOption {
+	return func(o *reloadOptions) {
+		o.factory = factory
+	}
+}
+
+type reloadState struct {
+	crypto sspvo.Crypto
+	info   *CertInfo
+	cert   []byte
+	key    []byte
+}
+
+//ReloadableCrypto Crypto structure that implements the sspvo.Crypto interface on top of cert and key files,
+//atomically swapping the underlying crypto when the files change.
+type ReloadableCrypto struct {
+	certFile string
+	keyFile  string
+	opts     *reloadOptions
+	state    atomic.Value
+
+	mu        sync.Mutex
+	warned    map[int]bool
+	now       func() time.Time
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+//NewReloadableCrypto Creating a new ReloadableCrypto from the PEM cert file and the optional PEM key file,
+//supports the following options: SetReloadInterval, SetExpiryInterval, SetExpiryThresholds, SetOnExpiry, SetOnReload, SetOnReloadError, SetCryptoFactory.
+func NewReloadableCrypto(certFile, keyFile string, opts ...ReloadOption) (*ReloadableCrypto, error) {
+	o := reloadOptions{
+		interval:       defaultReloadInterval,
+		expiryInterval: defaultExpiryInterval,
+		thresholds:     defaultExpiryThresholds,
+		factory:        NewGostCrypto,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	thresholds := append([]int(nil), o.thresholds...)
+	sort.Sort(sort.Reverse(sort.IntSlice(thresholds)))
+	o.thresholds = thresholds
+
+	c := &ReloadableCrypto{
+		certFile: certFile,
+		keyFile:  keyFile,
+		opts:     &o,
+		warned:   map[int]bool{},
+		now:      time.Now,
+	}
+
+	if _, err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	if o.interval > 0 || (o.expiryInterval > 0 && o.onExpiry != nil) {
+		c.stop = make(chan struct{})
+		c.done = make(chan struct{})
+		go c.watch()
+	}
+
+	return c, nil
+}
+
+func (c *ReloadableCrypto) watch() {
+	defer close(c.done)
+
+	var reload, expiry <-chan time.Time
+	if c.opts.interval > 0 {
+		ticker := time.NewTicker(c.opts.interval)
+		defer ticker.Stop()
+		reload = ticker.C
+	}
+	if c.opts.expiryInterval > 0 && c.opts.onExpiry != nil {
+		ticker := time.NewTicker(c.opts.expiryInterval)
+		defer ticker.Stop()
+		expiry = ticker.C
+	}
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-reload:
+			if _, err := c.Reload(); err != nil && c.opts.onError != nil {
+				c.opts.onError(err)
+			}
+		case <-expiry:
+			c.CheckExpiry()
+		}
+	}
+}
+
+//Close Stop watching the files, safe to call more than once.
+func (c *ReloadableCrypto) Close() error {
+	c.closeOnce.Do(func() {
+		if c.stop != nil {
+			close(c.stop)
+			<-c.done
+		}
+	})
+	return nil
+}
+
+//CheckExpiry Check the certificate in use for expiry and call the expiry callback for the thresholds reached.
+func (c *ReloadableCrypto) CheckExpiry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkExpiry(c.current().info)
+}
+
+//Reload Read the files and swap the underlying crypto if they have changed, reports whether the swap happened.
+func (c *ReloadableCrypto) Reload() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cert, err := ioutil.ReadFile(c.certFile)
+	if err != nil {
+		return false, fmt.Errorf("reloadable_crypto: %w", err)
+	}
+
+	var key []byte
+	if c.keyFile != "" {
+		key, err = ioutil.ReadFile(c.keyFile)
+		if err != nil {
+			return false, fmt.Errorf("reloadable_crypto: %w", err)
+		}
+	}
+
+	prev := c.current()
+	if prev != nil && bytes.Equal(prev.cert, cert) && bytes.Equal(prev.key, key) {
+		c.checkExpiry(prev.info)
+		return false, nil
+	}
+
+	opts := []Option{SetCert(string(cert))}
+	if key != nil {
+		opts = append(opts, SetKey(string(key)))
+	}
+	crypto, err := c.opts.factory(opts...)
+	if err != nil {
+		return false, fmt.Errorf("reloadable_crypto: %w", err)
+	}
+
+	info, err := ParseCertInfo(string(cert))
+	if err != nil {
+		return false, fmt.Errorf("reloadable_crypto: %w", err)
+	}
+
+	c.state.Store(&reloadState{
+		crypto: crypto,
+		info:   info,
+		cert:   cert,
+		key:    key,
+	})
+	c.warned = map[int]bool{}
+
+	if prev != nil && c.opts.onReload != nil {
+		c.opts.onReload(info)
+	}
+	c.checkExpiry(info)
+
+	return true, nil
+}
+
+func (c *ReloadableCrypto) checkExpiry(info *CertInfo) {
+	if c.opts.onExpiry == nil {
+		return
+	}
+
+	daysLeft := c.daysLeft(info)
+	for i := len(c.opts.thresholds) - 1; i >= 0; i-- {
+		threshold := c.opts.thresholds[i]
+		if daysLeft > threshold {
+			continue
+		}
+		if !c.warned[threshold] {
+			c.opts.onExpiry(info, daysLeft)
+		}
+		for _, t := range c.opts.thresholds[:i+1] {
+			c.warned[t] = true
+		}
+		return
+	}
+}
+
+func (c *ReloadableCrypto) daysLeft(info *CertInfo) int {
+	left := info.NotAfter.Sub(c.now())
+	days := int(left / (24 * time.Hour))
+	if left < 0 && left%(24*time.Hour) != 0 {
+		days--
+	}
+	return days
+}
+
+func (c *ReloadableCrypto) current() *reloadState {
+	state, _ := c.state.Load().(*reloadState)
+	return state
+}
+
+//Snapshot Receive the underlying crypto in use at the moment, it does not change during signing of one message.
+func (c *ReloadableCrypto) Snapshot() sspvo.Crypto {
+	return c.current().crypto
+}
+
+//CertInfo Receive the description of the certificate in use.
+func (c *ReloadableCrypto) CertInfo() *CertInfo {
+	return c.current().info
+}
+
+//DaysToExpiry Receive the number of whole days left until the certificate in use expires, negative when expired.
+func (c *ReloadableCrypto) DaysToExpiry() int {
+	return c.daysLeft(c.current().info)
+}
+
+//GetVerifyCrypto Get crypto instance for verify by cert
+func (c *ReloadableCrypto) GetVerifyCrypto(cert string) (sspvo.Crypto, error) {
+	return c.Snapshot().GetVerifyCrypto(cert)
+}
+
+//GetCert Receive the cert in use
+func (c *ReloadableCrypto) GetCert() string {
+	return c.Snapshot().GetCert()
+}
+
+//Hash Get hash(digest) of data with the crypto in use
+func (c *ReloadableCrypto) Hash(data []byte) (hash []byte) {
+	return c.Snapshot().Hash(data)
+}
+
+//Sign the digest with the key in use
+func (c *ReloadableCrypto) Sign(digest []byte) (sign []byte, err error) {
+	return c.Snapshot().Sign(digest)
+}
+
+//Verify the digest signature with the cert in use
+func (c *ReloadableCrypto) Verify(sign, digest []byte) (ok bool, err error) {
+	return c.Snapshot().Verify(sign, digest)
+}
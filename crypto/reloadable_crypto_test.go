@@ -0,0 +1,329 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/crypto"
+	"github.com/ftomza/go-sspvo/crypto/gostgen"
+)
+
+func writeIdentity(t *testing.T, dir string, identity *gostgen.Identity) (certFile, keyFile string) {
+	key, err := identity.KeyPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err = ioutil.WriteFile(certFile, []byte(identity.CertPEM()), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(keyFile, []byte(key), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func newIdentity(t *testing.T, cn string, notAfter time.Time) *gostgen.Identity {
+	identity, err := gostgen.NewCA(
+		gostgen.SetSubject(gostgen.Subject{CommonName: cn}),
+		gostgen.SetValidity(notAfter.AddDate(-1, 0, 0), notAfter),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return identity
+}
+
+func TestNewReloadableCrypto(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeIdentity(t, dir, newIdentity(t, "first", time.Now().AddDate(1, 0, 0)))
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+		wantErr  bool
+	}{
+		{
+			name:     "ok",
+			certFile: certFile,
+			keyFile:  keyFile,
+		},
+		{
+			name:     "ok verify only",
+			certFile: certFile,
+		},
+		{
+			name:     "fail cert file",
+			certFile: filepath.Join(dir, "none.pem"),
+			wantErr:  true,
+		},
+		{
+			name:     "fail key file",
+			certFile: certFile,
+			keyFile:  filepath.Join(dir, "none.pem"),
+			wantErr:  true,
+		},
+		{
+			name:     "fail key",
+			certFile: certFile,
+			keyFile:  certFile,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := crypto.NewReloadableCrypto(tt.certFile, tt.keyFile, crypto.SetReloadInterval(0))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewReloadableCrypto() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && got.CertInfo().Subject.CommonName != "first" {
+				t.Errorf("NewReloadableCrypto() crypto.CertInfo = %v", got.CertInfo())
+			}
+		})
+	}
+}
+
+func TestReloadableCrypto_Reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	first := newIdentity(t, "first", time.Now().AddDate(1, 0, 0))
+	second := newIdentity(t, "second", time.Now().AddDate(2, 0, 0))
+	certFile, keyFile := writeIdentity(t, dir, first)
+
+	var reloaded []string
+	var errs []error
+	c, err := crypto.NewReloadableCrypto(certFile, keyFile,
+		crypto.SetReloadInterval(0),
+		crypto.SetOnReload(func(info *crypto.CertInfo) { reloaded = append(reloaded, info.Subject.CommonName) }),
+		crypto.SetOnReloadError(func(err error) { errs = append(errs, err) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swapped, err := c.Reload()
+	if err != nil || swapped {
+		t.Errorf("Reload() unchanged swapped = %v, error = %v", swapped, err)
+	}
+
+	before := c.Snapshot()
+	digest := c.Hash([]byte("test"))
+	sign, err := c.Sign(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondKey, _ := second.KeyPEM()
+	if err = ioutil.WriteFile(certFile, []byte(second.CertPEM()), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	swapped, err = c.Reload()
+	if err == nil || swapped {
+		t.Errorf("Reload() half rotated swapped = %v, error = %v", swapped, err)
+	}
+	if c.Snapshot() != before {
+		t.Errorf("Reload() half rotated must keep the previous crypto")
+	}
+
+	if err = ioutil.WriteFile(keyFile, []byte(secondKey), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	swapped, err = c.Reload()
+	if err != nil || !swapped {
+		t.Errorf("Reload() rotated swapped = %v, error = %v", swapped, err)
+	}
+	if c.GetCert() != second.CertPEM() {
+		t.Errorf("Reload() GetCert not rotated")
+	}
+	if ok, _ := c.Verify(sign, digest); ok {
+		t.Errorf("Reload() old signature must not verify with the new key")
+	}
+	if ok, _ := before.Verify(sign, digest); !ok {
+		t.Errorf("Reload() snapshot taken before must stay usable")
+	}
+	if !reflect.DeepEqual(reloaded, []string{"second"}) {
+		t.Errorf("Reload() onReload = %v", reloaded)
+	}
+
+	verify, err := c.GetVerifyCrypto(first.CertPEM())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := verify.Verify(sign, digest); !ok {
+		t.Errorf("GetVerifyCrypto() must verify the old signature")
+	}
+}
+
+func TestReloadableCrypto_watch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeIdentity(t, dir, newIdentity(t, "first", time.Now().AddDate(1, 0, 0)))
+
+	reloaded := make(chan string, 1)
+	c, err := crypto.NewReloadableCrypto(certFile, keyFile,
+		crypto.SetReloadInterval(10*time.Millisecond),
+		crypto.SetOnReload(func(info *crypto.CertInfo) { reloaded <- info.Subject.CommonName }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	writeIdentity(t, dir, newIdentity(t, "second", time.Now().AddDate(1, 0, 0)))
+
+	select {
+	case cn := <-reloaded:
+		if cn != "second" {
+			t.Errorf("watch() reloaded = %v", cn)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("watch() files not reloaded")
+	}
+}
+
+func TestReloadableCrypto_watchExpiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	notAfter := time.Now().AddDate(0, 0, 40).Truncate(time.Second)
+	certFile, keyFile := writeIdentity(t, dir, newIdentity(t, "first", notAfter))
+
+	warned := make(chan int, 1)
+	c, err := crypto.NewReloadableCrypto(certFile, keyFile,
+		crypto.SetReloadInterval(0),
+		crypto.SetExpiryInterval(10*time.Millisecond),
+		crypto.SetExpiryThresholds(7),
+		crypto.SetOnExpiry(func(info *crypto.CertInfo, daysLeft int) { warned <- daysLeft }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetNow(func() time.Time { return notAfter.AddDate(0, 0, -5) })
+
+	select {
+	case days := <-warned:
+		if days != 5 {
+			t.Errorf("onExpiry() days = %v, want 5", days)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("watch() expiry not checked without reload")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = c.Close()
+		}()
+	}
+	wg.Wait()
+	if err = c.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestReloadableCrypto_checkExpiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reloadable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	notAfter := time.Now().AddDate(0, 0, 40).Truncate(time.Second)
+	certFile, keyFile := writeIdentity(t, dir, newIdentity(t, "first", notAfter))
+
+	var warned []int
+	c, err := crypto.NewReloadableCrypto(certFile, keyFile,
+		crypto.SetReloadInterval(0),
+		crypto.SetExpiryThresholds(7, 30, 1),
+		crypto.SetOnExpiry(func(info *crypto.CertInfo, daysLeft int) { warned = append(warned, daysLeft) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantDays   int
+		wantWarned []int
+	}{
+		{
+			name:       "no warn",
+			now:        notAfter.AddDate(0, 0, -35),
+			wantDays:   35,
+			wantWarned: nil,
+		},
+		{
+			name:       "warn 30",
+			now:        notAfter.AddDate(0, 0, -29),
+			wantDays:   29,
+			wantWarned: []int{29},
+		},
+		{
+			name:       "warn 30 once",
+			now:        notAfter.AddDate(0, 0, -20),
+			wantDays:   20,
+			wantWarned: []int{29},
+		},
+		{
+			name:       "warn 1 skip 7",
+			now:        notAfter.AddDate(0, 0, -1),
+			wantDays:   1,
+			wantWarned: []int{29, 1},
+		},
+		{
+			name:       "expired",
+			now:        notAfter.Add(time.Hour),
+			wantDays:   -1,
+			wantWarned: []int{29, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.SetNow(func() time.Time { return tt.now })
+			c.CheckExpiry()
+			if got := c.DaysToExpiry(); got != tt.wantDays {
+				t.Errorf("DaysToExpiry() = %v, want %v", got, tt.wantDays)
+			}
+			if !reflect.DeepEqual(warned, tt.wantWarned) {
+				t.Errorf("onExpiry() = %v, want %v", warned, tt.wantWarned)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/pem"
+	"sync"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+//DefaultVerifierCacheSize The number of verifiers kept by the cache shared by all GostCrypto instances.
+const DefaultVerifierCacheSize = 64
+
+var defaultVerifierCache = NewVerifierCache(DefaultVerifierCacheSize)
+
+//DefaultVerifierCache Receive the cache used by GostCrypto.GetVerifyCrypto when SetVerifierCache is not set.
+func DefaultVerifierCache() *VerifierCache {
+	return defaultVerifierCache
+}
+
+//VerifierCacheStats Counters of the verifier cache.
+type VerifierCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+	Capacity  int
+}
+
+type verifierEntry struct {
+	fingerprint [sha256.Size]byte
+	crypto      sspvo.Crypto
+}
+
+//VerifierCache LRU cache of the verifiers built from certificates, keyed by the SHA-256 fingerprint of the certificate.
+//The cache is safe for concurrent use.
+type VerifierCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[[sha256.Size]byte]*list.Element
+	stats    VerifierCacheStats
+}
+
+//NewVerifierCache Creating a new VerifierCache that keeps at most size verifiers, zero or less disables caching.
+func NewVerifierCache(size int) *VerifierCache {
+	if size < 0 {
+		size = 0
+	}
+	return &VerifierCache{
+		capacity: size,
+		order:    list.New(),
+		entries:  map[[sha256.Size]byte]*list.Element{},
+	}
+}
+
+//Get Receive the verifier for the cert, build it with the factory when it is not in the cache.
+//Errors of the factory are not cached.
+func (c *VerifierCache) Get(cert string, factory func(cert string) (sspvo.Crypto, error)) (sspvo.Crypto, error) {
+	fingerprint := certFingerprint(cert)
+
+	c.mu.Lock()
+	if el, ok := c.entries[fingerprint]; ok {
+		c.order.MoveToFront(el)
+		c.stats.Hits++
+		c.mu.Unlock()
+		return el.Value.(*verifierEntry).crypto, nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	crypto, err := factory(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capacity == 0 {
+		return crypto, nil
+	}
+	if el, ok := c.entries[fingerprint]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*verifierEntry).crypto, nil
+	}
+
+	c.entries[fingerprint] = c.order.PushFront(&verifierEntry{fingerprint: fingerprint, crypto: crypto})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*verifierEntry).fingerprint)
+		c.stats.Evictions++
+	}
+
+	return crypto, nil
+}
+
+//Len Receive the number of verifiers in the cache.
+func (c *VerifierCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+//Purge Remove all verifiers from the cache, the counters are kept.
+func (c *VerifierCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = map[[sha256.Size]byte]*list.Element{}
+}
+
+//Stats Receive the counters of the cache.
+func (c *VerifierCache) Stats() VerifierCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.order.Len()
+	stats.Capacity = c.capacity
+	return stats
+}
+
+//certFingerprint SHA-256 of the DER certificate, the PEM text itself when it can not be decoded.
+func certFingerprint(cert string) [sha256.Size]byte {
+	if block, _ := pem.Decode([]byte(cert)); block != nil {
+		return sha256.Sum256(block.Bytes)
+	}
+	return sha256.Sum256([]byte(cert))
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package crypto_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/crypto"
+)
+
+func TestVerifierCache_Get(t *testing.T) {
+	certs := []string{
+		newIdentity(t, "first", time.Now().AddDate(1, 0, 0)).CertPEM(),
+		newIdentity(t, "second", time.Now().AddDate(1, 0, 0)).CertPEM(),
+		newIdentity(t, "third", time.Now().AddDate(1, 0, 0)).CertPEM(),
+	}
+
+	tests := []struct {
+		name      string
+		size      int
+		gets      []int
+		wantBuild int
+		wantStats crypto.VerifierCacheStats
+	}{
+		{
+			name:      "hit",
+			size:      2,
+			gets:      []int{0, 0, 0},
+			wantBuild: 1,
+			wantStats: crypto.VerifierCacheStats{Hits: 2, Misses: 1, Size: 1, Capacity: 2},
+		},
+		{
+			name:      "lru eviction",
+			size:      2,
+			gets:      []int{0, 1, 0, 2, 0, 1},
+			wantBuild: 4,
+			wantStats: crypto.VerifierCacheStats{Hits: 2, Misses: 4, Evictions: 2, Size: 2, Capacity: 2},
+		},
+		{
+			name:      "disabled",
+			size:      0,
+			gets:      []int{0, 0},
+			wantBuild: 2,
+			wantStats: crypto.VerifierCacheStats{Misses: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := crypto.NewVerifierCache(tt.size)
+			build := 0
+			factory := func(cert string) (sspvo.Crypto, error) {
+				build++
+				return crypto.NewGostCrypto(crypto.SetCert(cert))
+			}
+			for _, i := range tt.gets {
+				got, err := cache.Get(certs[i], factory)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.GetCert() != certs[i] {
+					t.Errorf("Get() returned verifier of other cert")
+				}
+			}
+			if build != tt.wantBuild {
+				t.Errorf("Get() build = %v, want %v", build, tt.wantBuild)
+			}
+			if got := cache.Stats(); !reflect.DeepEqual(got, tt.wantStats) {
+				t.Errorf("Stats() = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestVerifierCache_Get_error(t *testing.T) {
+	cache := crypto.NewVerifierCache(2)
+	wantErr := errors.New("bad cert")
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Get("BAD", func(string) (sspvo.Crypto, error) { return nil, wantErr }); err != wantErr {
+			t.Errorf("Get() error = %v, want %v", err, wantErr)
+		}
+	}
+	if got := cache.Stats(); got.Misses != 2 || got.Size != 0 {
+		t.Errorf("Stats() = %+v, errors must not be cached", got)
+	}
+}
+
+func TestVerifierCache_Purge(t *testing.T) {
+	cache := crypto.NewVerifierCache(2)
+	if _, err := cache.Get(crypto.ValidCert, func(cert string) (sspvo.Crypto, error) { return crypto.NewGostCrypto(crypto.SetCert(cert)) }); err != nil {
+		t.Fatal(err)
+	}
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Errorf("Purge() Len = %v, want 0", cache.Len())
+	}
+}
+
+func TestGostCrypto_GetVerifyCrypto_cache(t *testing.T) {
+	cache := crypto.NewVerifierCache(4)
+	c, err := crypto.NewGostCrypto(crypto.SetCert(crypto.ValidCert), crypto.SetKey(crypto.ValidKey), crypto.SetVerifierCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			verify, err := c.GetVerifyCrypto(crypto.ValidCert)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if ok, _ := verify.Verify(crypto.SignExtern, crypto.ValidHash); !ok {
+				t.Errorf("GetVerifyCrypto() verifier does not verify")
+			}
+		}()
+	}
+	wg.Wait()
+
+	first, _ := c.GetVerifyCrypto(crypto.ValidCert)
+	second, _ := c.GetVerifyCrypto(crypto.ValidCert)
+	if first != second {
+		t.Errorf("GetVerifyCrypto() must reuse the cached verifier")
+	}
+	if got := cache.Stats(); got.Size != 1 || got.Hits+got.Misses != workers+2 {
+		t.Errorf("Stats() = %+v", got)
+	}
+}
@@ -15,7 +15,11 @@ import (
 
 func main() {
 
-	go test_server_epgu.RunServerDefault()
+	go func() {
+		if err := test_server_epgu.RunServerDefault(); err != nil {
+			log.Fatal(err)
+		}
+	}()
 
 	restyClient := resty.New()
 	restyClient.SetHostURL("http://localhost:7777")
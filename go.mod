@@ -7,5 +7,9 @@ require (
 	github.com/ftomza/gogost v0.0.0-20200923131839-93b36ba10d5f
 	github.com/go-resty/resty/v2 v2.3.0
 	github.com/gofiber/fiber/v2 v2.1.0
+	github.com/prometheus/client_golang v1.8.0
+	github.com/sirupsen/logrus v1.7.0
 	github.com/stretchr/testify v1.6.1
+	go.opentelemetry.io/otel v0.13.0
+	go.uber.org/zap v1.16.0
 )
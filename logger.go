@@ -0,0 +1,78 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package sspvo
+
+import "context"
+
+//LogLevel Severity of the event.
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+func (e LogLevel) String() string {
+	switch e {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	case LogError:
+		return "error"
+	}
+	return "unknown"
+}
+
+//Events written by the library.
+const (
+	EventMessageSent      = "sspvo: message sent"
+	EventResponseVerified = "sspvo: response verified"
+	EventSign             = "sspvo: digest signed"
+	EventVerify           = "sspvo: signature verified"
+	EventClientWarning    = "sspvo: client warning"
+)
+
+//Keys of the fields of the events.
+const (
+	LogKeyPathMethod = "path_method"
+	LogKeyAction     = "action"
+	LogKeyDatatype   = "data_type"
+	LogKeyCLS        = "cls"
+	LogKeyIDJWT      = "IDJWT"
+	LogKeyCode       = "code"
+	LogKeyLatency    = "latency"
+	LogKeyVerified   = "verified"
+	LogKeyError      = "error"
+)
+
+//LogField Key and value of the structured event.
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+//Logger Receiver of the structured events of the library, adapters for the common loggers are in the package logger.
+//Nothing is logged unless the logger is set.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, fields ...LogField)
+}
+
+//Loggable Implemented by the responses and other parts that accept the logger of the client.
+type Loggable interface {
+	SetLogger(logger Logger)
+}
+
+//FieldsMessage Implemented by the messages that expose their JWT fields, used to describe the message in the events.
+type FieldsMessage interface {
+	JWTFields() JWTFields
+}
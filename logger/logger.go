@@ -0,0 +1,46 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+//Package logger Adapters of the common Go loggers to the sspvo.Logger interface.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+//Std Adapter of the standard log.Logger, the events are written as "level msg key=value ...".
+type Std struct {
+	logger *log.Logger
+	level  sspvo.LogLevel
+}
+
+//NewStd Creating a new Std adapter writing the events with the level not below the level, nil logger means the standard logger.
+func NewStd(logger *log.Logger, level sspvo.LogLevel) *Std {
+	if logger == nil {
+		logger = log.New(log.Writer(), log.Prefix(), log.Flags())
+	}
+	return &Std{logger: logger, level: level}
+}
+
+func (l *Std) Log(_ context.Context, level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	if level < l.level {
+		return
+	}
+	b := strings.Builder{}
+	b.WriteString(level.String())
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for _, f := range fields {
+		_, _ = fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	l.logger.Print(b.String())
+}
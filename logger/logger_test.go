@@ -0,0 +1,93 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+
+	"github.com/ftomza/go-sspvo"
+
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var fields = []sspvo.LogField{
+	{Key: sspvo.LogKeyPathMethod, Value: "token/new"},
+	{Key: sspvo.LogKeyCode, Value: 200},
+}
+
+func TestStd_Log(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewStd(log.New(buf, "", 0), sspvo.LogInfo)
+
+	l.Log(context.Background(), sspvo.LogDebug, sspvo.EventMessageSent, fields...)
+	l.Log(context.Background(), sspvo.LogWarn, sspvo.EventMessageSent, fields...)
+
+	want := "warn sspvo: message sent path_method=token/new code=200\n"
+	if buf.String() != want {
+		t.Errorf("Log() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestZap_Log(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := NewZap(zap.New(core))
+
+	tests := []struct {
+		level sspvo.LogLevel
+		want  zapcore.Level
+	}{
+		{level: sspvo.LogDebug, want: zapcore.DebugLevel},
+		{level: sspvo.LogInfo, want: zapcore.InfoLevel},
+		{level: sspvo.LogWarn, want: zapcore.WarnLevel},
+		{level: sspvo.LogError, want: zapcore.ErrorLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			l.Log(context.Background(), tt.level, sspvo.EventMessageSent, fields...)
+			entries := logs.TakeAll()
+			if len(entries) != 1 || entries[0].Level != tt.want || entries[0].Message != sspvo.EventMessageSent {
+				t.Fatalf("Log() entries = %+v", entries)
+			}
+			if got := entries[0].ContextMap()[sspvo.LogKeyPathMethod]; got != "token/new" {
+				t.Errorf("Log() %s = %v", sspvo.LogKeyPathMethod, got)
+			}
+		})
+	}
+}
+
+func TestLogrus_Log(t *testing.T) {
+	logger, hook := logrustest.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	l := NewLogrus(logger)
+
+	tests := []struct {
+		level sspvo.LogLevel
+		want  logrus.Level
+	}{
+		{level: sspvo.LogDebug, want: logrus.DebugLevel},
+		{level: sspvo.LogInfo, want: logrus.InfoLevel},
+		{level: sspvo.LogWarn, want: logrus.WarnLevel},
+		{level: sspvo.LogError, want: logrus.ErrorLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			l.Log(context.Background(), tt.level, sspvo.EventResponseVerified, fields...)
+			entry := hook.LastEntry()
+			if entry == nil || entry.Level != tt.want || entry.Message != sspvo.EventResponseVerified || entry.Data[sspvo.LogKeyCode] != 200 {
+				t.Errorf("Log() entry = %+v", entry)
+			}
+		})
+	}
+}
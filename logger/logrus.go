@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"context"
+
+	"github.com/ftomza/go-sspvo"
+
+	"github.com/sirupsen/logrus"
+)
+
+//Logrus Adapter of the logrus.FieldLogger.
+type Logrus struct {
+	logger logrus.FieldLogger
+}
+
+//NewLogrus Creating a new Logrus adapter.
+func NewLogrus(logger logrus.FieldLogger) *Logrus {
+	return &Logrus{logger: logger}
+}
+
+func (l *Logrus) Log(ctx context.Context, level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	logrusFields := make(logrus.Fields, len(fields))
+	for _, f := range fields {
+		logrusFields[f.Key] = f.Value
+	}
+	entry := l.logger.WithFields(logrusFields)
+	if ctx != nil {
+		entry = entry.WithContext(ctx)
+	}
+	switch level {
+	case sspvo.LogDebug:
+		entry.Debug(msg)
+	case sspvo.LogInfo:
+		entry.Info(msg)
+	case sspvo.LogWarn:
+		entry.Warn(msg)
+	default:
+		entry.Error(msg)
+	}
+}
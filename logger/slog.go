@@ -0,0 +1,54 @@
+//go:build go1.21
+//+build go1.21
+
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+//Slog Adapter of the slog.Logger, available with Go 1.21 and newer.
+type Slog struct {
+	logger *slog.Logger
+}
+
+//NewSlog Creating a new Slog adapter, nil logger means slog.Default.
+func NewSlog(logger *slog.Logger) *Slog {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Slog{logger: logger}
+}
+
+func (l *Slog) Log(ctx context.Context, level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	l.logger.LogAttrs(ctx, slogLevel(level), msg, attrs...)
+}
+
+func slogLevel(level sspvo.LogLevel) slog.Level {
+	switch level {
+	case sspvo.LogDebug:
+		return slog.LevelDebug
+	case sspvo.LogInfo:
+		return slog.LevelInfo
+	case sspvo.LogWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
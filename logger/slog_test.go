@@ -0,0 +1,35 @@
+//go:build go1.21
+//+build go1.21
+
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ftomza/go-sspvo"
+)
+
+func TestSlog_Log(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewSlog(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	l.Log(context.Background(), sspvo.LogDebug, sspvo.EventSign)
+	l.Log(context.Background(), sspvo.LogWarn, sspvo.EventMessageSent, fields...)
+
+	got := buf.String()
+	if strings.Contains(got, sspvo.EventSign) || !strings.Contains(got, "level=WARN") ||
+		!strings.Contains(got, "path_method=token/new") || !strings.Contains(got, "code=200") {
+		t.Errorf("Log() = %s", got)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package logger
+
+import (
+	"context"
+
+	"github.com/ftomza/go-sspvo"
+
+	"go.uber.org/zap"
+)
+
+//Zap Adapter of the zap.Logger.
+type Zap struct {
+	logger *zap.Logger
+}
+
+//NewZap Creating a new Zap adapter.
+func NewZap(logger *zap.Logger) *Zap {
+	return &Zap{logger: logger}
+}
+
+func (l *Zap) Log(_ context.Context, level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for _, f := range fields {
+		zapFields = append(zapFields, zap.Any(f.Key, f.Value))
+	}
+	switch level {
+	case sspvo.LogDebug:
+		l.logger.Debug(msg, zapFields...)
+	case sspvo.LogInfo:
+		l.logger.Info(msg, zapFields...)
+	case sspvo.LogWarn:
+		l.logger.Warn(msg, zapFields...)
+	default:
+		l.logger.Error(msg, zapFields...)
+	}
+}
@@ -8,12 +8,14 @@
 package message
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 
 	"github.com/ftomza/go-sspvo/response"
+	"github.com/ftomza/go-sspvo/tracing"
 
 	"github.com/ftomza/go-sspvo"
 )
@@ -85,6 +87,15 @@ func (m *Message) GetJWT() ([]byte, error) {
 	return json.Marshal(m.Fields)
 }
 
+//JWTFields Receive a copy of the fields of the message, see sspvo.FieldsMessage.
+func (m *Message) JWTFields() sspvo.JWTFields {
+	fields := make(sspvo.JWTFields, len(m.Fields))
+	for k, v := range m.Fields {
+		fields[k] = v
+	}
+	return fields
+}
+
 func (m *Message) Response() sspvo.Response {
 	return response.NewResponse()
 }
@@ -101,6 +112,16 @@ func (m *SignMessage) UpdateJWTFields(fields ...sspvo.Fields) sspvo.Message {
 }
 
 func (m *SignMessage) GetJWT() ([]byte, error) {
+	return m.GetJWTContext(context.Background())
+}
+
+//GetJWTContext Sign the token within the context of the sending, the signing span is a child of the span in the context.
+func (m *SignMessage) GetJWTContext(ctx context.Context) (body []byte, err error) {
+	_, span := tracing.Start(ctx, tracing.SpanSign, tracing.FieldsAttributes(m.Fields)...)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	token, err := m.signToken()
 	if err != nil {
 		return nil, fmt.Errorf("SignMessage: %w", err)
@@ -121,7 +142,12 @@ func (m *SignMessage) Init(crypto sspvo.Crypto, data []byte) {
 }
 
 func (m *SignMessage) signToken() (*sspvo.Token, error) {
-	m.UpdateJWTFields(setCert(m.crypto.GetCert()))
+	crypto := m.crypto
+	if snapshot, ok := crypto.(sspvo.CryptoSnapshot); ok {
+		crypto = snapshot.Snapshot()
+	}
+
+	m.UpdateJWTFields(setCert(crypto.GetCert()))
 
 	jsonHeader, err := json.Marshal(m.Fields)
 	if err != nil {
@@ -135,12 +161,12 @@ func (m *SignMessage) signToken() (*sspvo.Token, error) {
 	}
 
 	data4sign := []byte(fmt.Sprintf("%s.%s", header, payload))
-	digest := m.crypto.Hash(data4sign)
-	signDigest, err := m.crypto.Sign(digest)
+	digest := crypto.Hash(data4sign)
+	signDigest, err := crypto.Sign(digest)
 	if err != nil {
 		return nil, err
 	}
-	if ok, err := m.crypto.Verify(signDigest, digest); err != nil {
+	if ok, err := crypto.Verify(signDigest, digest); err != nil {
 		return nil, err
 	} else if !ok {
 		return nil, sspvo.ErrBadSign
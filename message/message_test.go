@@ -153,6 +153,32 @@ func (suite *MessageTestSuite) TestMessage_signToken() {
 
 		suite.crypto.AssertExpectations(suite.T())
 	})
+	suite.Run("ok snapshot", func() {
+		suite.crypto.On("GetCert").Return("TEST").Once()
+		suite.crypto.On("Hash", mock.Anything).Return([]byte("TEST")).Once()
+		suite.crypto.On("Sign", mock.Anything).Return([]byte("TEST"), nil).Once()
+		suite.crypto.On("Verify", mock.Anything, mock.Anything).Return(true, nil).Once()
+		msg := &SignMessage{
+			Message: Message{
+				Fields: sspvo.JWTFields{},
+			},
+			crypto: snapshotCrypto{snapshot: suite.crypto},
+		}
+		token, err := msg.signToken()
+		suite.NoError(err)
+		suite.Equal("VEVTVA==", token.Sign)
+
+		suite.crypto.AssertExpectations(suite.T())
+	})
+}
+
+type snapshotCrypto struct {
+	sspvo.Crypto
+	snapshot sspvo.Crypto
+}
+
+func (c snapshotCrypto) Snapshot() sspvo.Crypto {
+	return c.snapshot
 }
 
 func (suite *MessageTestSuite) Test_setCert() {
@@ -0,0 +1,246 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+//Package metrics Prometheus metrics of the traffic of sspvo.Client.
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statusError = "error"
+
+type options struct {
+	namespace   string
+	buckets     []float64
+	constLabels prometheus.Labels
+}
+
+type Option func(*options)
+
+//SetNamespace To set the namespace option of the metric names, by default "sspvo".
+func SetNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+//SetBuckets To set the buckets option of the latency histogram in seconds, by default prometheus.DefBuckets.
+func SetBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+//SetConstLabels To set the labels added to every metric, for example the organization.
+func SetConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		o.constLabels = labels
+	}
+}
+
+//Collector Metrics of the clients instrumented by Instrument, implements prometheus.Collector.
+type Collector struct {
+	requests       *prometheus.CounterVec
+	latency        *prometheus.HistogramVec
+	verifyFailures *prometheus.CounterVec
+	queueDepth     prometheus.Gauge
+}
+
+//NewCollector Creating a new Collector, supports the following options: SetNamespace, SetBuckets, SetConstLabels.
+func NewCollector(opts ...Option) *Collector {
+	o := options{
+		namespace: "sspvo",
+		buckets:   prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	labels := []string{"path_method", "action", "data_type", "status"}
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "requests_total",
+			Help:        "Number of the messages sent to the service by path method, action, datatype and response code.",
+			ConstLabels: o.constLabels,
+		}, labels),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "request_duration_seconds",
+			Help:        "Latency of sending the messages to the service including signing.",
+			Buckets:     o.buckets,
+			ConstLabels: o.constLabels,
+		}, labels),
+		verifyFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "verify_failures_total",
+			Help:        "Number of the responses whose ResponseToken signature did not match (sspvo.ErrBadSign), errors of reading the token are not counted.",
+			ConstLabels: o.constLabels,
+		}, []string{"path_method", "action", "data_type"}),
+		queueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "queue_depth",
+			Help:        "Number of the messages waiting for confirmation, sampled from InfoAllMessage.",
+			ConstLabels: o.constLabels,
+		}),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.verifyFailures.Describe(ch)
+	c.queueDepth.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.verifyFailures.Collect(ch)
+	c.queueDepth.Collect(ch)
+}
+
+//Instrument Wrap the client, every Send is counted by the collector.
+func (c *Collector) Instrument(client sspvo.Client) *Client {
+	return &Client{Client: client, collector: c}
+}
+
+//Client Client structure that implements the sspvo.Client interface and records the metrics of the wrapped client.
+type Client struct {
+	sspvo.Client
+	collector *Collector
+}
+
+//Send the message with the wrapped client and record its status and latency, the queue depth is taken from the responses of InfoAllMessage.
+func (c *Client) Send(ctx context.Context, msg sspvo.Message) sspvo.Response {
+	start := time.Now()
+	res := c.Client.Send(ctx, msg)
+	latency := time.Since(start)
+
+	labels := messageLabels(msg)
+	status := statusError
+	resp := res.ClientResponse()
+	if resp != nil && res.Error() == nil {
+		status = strconv.Itoa(resp.Code)
+	}
+	c.collector.requests.WithLabelValues(labels[0], labels[1], labels[2], status).Inc()
+	c.collector.latency.WithLabelValues(labels[0], labels[1], labels[2], status).Observe(latency.Seconds())
+
+	if _, ok := msg.(*message.InfoAllMessage); ok && status != statusError && resp.Code == http.StatusOK {
+		if depth, err := queueDepth(resp.Body); err == nil {
+			c.collector.queueDepth.Set(float64(depth))
+		}
+	}
+
+	return &response{Response: res, collector: c.collector, labels: labels}
+}
+
+//SampleQueue Send InfoAllMessage to update the queue depth and receive it.
+func (c *Client) SampleQueue(ctx context.Context) (int, error) {
+	data, err := c.Send(ctx, message.NewInfoAllMessage()).Data()
+	if err != nil {
+		return 0, fmt.Errorf("metrics: %w", err)
+	}
+	depth, err := queueDepth(data)
+	if err != nil {
+		return 0, fmt.Errorf("metrics: %w", err)
+	}
+	return depth, nil
+}
+
+//queueDepth Read the number of the messages from the response of InfoAllMessage,
+//the service returns either the number or the list of the IDJWT.
+func queueDepth(data []byte) (int, error) {
+	info := struct {
+		Messages json.RawMessage `json:"Messages"`
+	}{}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return 0, err
+	}
+	var count int
+	if err := json.Unmarshal(info.Messages, &count); err == nil {
+		return count, nil
+	}
+	var ids []json.RawMessage
+	if err := json.Unmarshal(info.Messages, &ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+//messageLabels Receive the path method, action and datatype of the message.
+func messageLabels(msg sspvo.Message) [3]string {
+	labels := [3]string{msg.PathMethod()}
+	if fieldsMessage, ok := msg.(sspvo.FieldsMessage); ok {
+		fields := fieldsMessage.JWTFields()
+		labels[1] = fmt.Sprint(valueOrEmpty(fields[sspvo.FieldAction]))
+		labels[2] = fmt.Sprint(valueOrEmpty(fields[sspvo.FieldDataType]))
+	}
+	return labels
+}
+
+func valueOrEmpty(v interface{}) interface{} {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+//response Response wrapper counting the failed verification of the ResponseToken once,
+//the optional interfaces of the wrapped response are forwarded.
+type response struct {
+	sspvo.Response
+	collector *Collector
+	labels    [3]string
+	once      sync.Once
+}
+
+func (r *response) Data() ([]byte, error) {
+	data, err := r.Response.Data()
+	if errors.Is(err, sspvo.ErrBadSign) {
+		r.once.Do(func() {
+			r.collector.verifyFailures.WithLabelValues(r.labels[0], r.labels[1], r.labels[2]).Inc()
+		})
+	}
+	return data, err
+}
+
+//SetContext Pass the context to the wrapped response if it implements sspvo.ContextResponse.
+func (r *response) SetContext(ctx context.Context) {
+	if contextResponse, ok := r.Response.(sspvo.ContextResponse); ok {
+		contextResponse.SetContext(ctx)
+	}
+}
+
+//SetLogger Pass the logger to the wrapped response if it implements sspvo.Loggable.
+func (r *response) SetLogger(logger sspvo.Logger) {
+	if loggable, ok := r.Response.(sspvo.Loggable); ok {
+		loggable.SetLogger(logger)
+	}
+}
+
+//Unwrap Receive the wrapped response, e.g. to reach the methods of its own type.
+func (r *response) Unwrap() sspvo.Response {
+	return r.Response
+}
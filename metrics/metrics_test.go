@@ -0,0 +1,144 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+
+	collector := NewCollector(SetConstLabels(prometheus.Labels{"ogrn": ts.OGRN}))
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatal(err)
+	}
+	client := collector.Instrument(ts.Client)
+
+	add := message.NewActionMessage(ts.Crypto, message.ActionAdd, message.DatatypeSubdivisionOrg,
+		[]byte("<PackageData><SubdivisionOrg><UID>1</UID></SubdivisionOrg></PackageData>"))
+	if _, err := client.Send(ctx, add).Data(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Send(ctx, message.NewCLSMessage("Unknown")).Data(); err == nil {
+		t.Fatal("unknown classifier must fail")
+	}
+
+	depth, err := client.SampleQueue(ctx)
+	if err != nil || depth != 1 {
+		t.Fatalf("SampleQueue() = %d, %v", depth, err)
+	}
+
+	ts.SetFault(test_server_epgu.EndpointTokenInfo, test_server_epgu.Fault{InvalidSignature: true, Times: 1})
+	info := client.Send(ctx, message.NewInfoMessage(ts.Crypto, ts.Queue()[0].IDJWT))
+	for i := 0; i < 2; i++ {
+		if _, err = info.Data(); !errors.Is(err, sspvo.ErrBadSign) {
+			t.Fatalf("Info Data() error = %v, want %v", err, sspvo.ErrBadSign)
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client.Send(cancelled, message.NewInfoAllMessage())
+
+	tests := []struct {
+		name   string
+		metric prometheus.Collector
+		want   float64
+	}{
+		{name: "add", metric: collector.requests.WithLabelValues("token/new", "Add", "subdivision_org", "200"), want: 1},
+		{name: "cls not found", metric: collector.requests.WithLabelValues("cls/request", "", "", "404"), want: 1},
+		{name: "info all", metric: collector.requests.WithLabelValues("token/info", "", "", "200"), want: 1},
+		{name: "info", metric: collector.requests.WithLabelValues("token/info", "GetMessage", "", "200"), want: 1},
+		{name: "transport error", metric: collector.requests.WithLabelValues("token/info", "", "", "error"), want: 1},
+		{name: "verify failures", metric: collector.verifyFailures.WithLabelValues("token/info", "GetMessage", ""), want: 1},
+		{name: "queue depth", metric: collector.queueDepth, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := testutil.ToFloat64(tt.metric); got != tt.want {
+				t.Errorf("metric = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if n, err := testutil.GatherAndCount(registry, "sspvo_client_request_duration_seconds"); err != nil || n != 5 {
+		t.Errorf("GatherAndCount() = %d, %v", n, err)
+	}
+}
+
+func Test_queueDepth(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{name: "ok number", data: `{"Messages": 2}`, want: 2},
+		{name: "ok list", data: `{"Messages":[1,2,3]}`, want: 3},
+		{name: "fail", data: `{"Messages":"x"}`, wantErr: true},
+		{name: "fail json", data: `x`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := queueDepth([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("queueDepth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("queueDepth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type ctxKey struct{}
+
+type loggableResponse struct {
+	sspvo.Response
+	ctx    context.Context
+	logger sspvo.Logger
+}
+
+func (r *loggableResponse) SetContext(ctx context.Context)                                 { r.ctx = ctx }
+func (r *loggableResponse) SetLogger(logger sspvo.Logger)                                  { r.logger = logger }
+func (r *loggableResponse) Log(context.Context, sspvo.LogLevel, string, ...sspvo.LogField) {}
+
+func Test_response_forward(t *testing.T) {
+	inner := &loggableResponse{}
+	var res sspvo.Response = &response{Response: inner, collector: NewCollector()}
+
+	contextResponse, ok := res.(sspvo.ContextResponse)
+	if !ok {
+		t.Fatal("response must implement sspvo.ContextResponse")
+	}
+	loggable, ok := res.(sspvo.Loggable)
+	if !ok {
+		t.Fatal("response must implement sspvo.Loggable")
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, 1)
+	contextResponse.SetContext(ctx)
+	loggable.SetLogger(inner)
+	if inner.ctx != ctx || inner.logger != inner {
+		t.Errorf("forwarded = %v %v", inner.ctx, inner.logger)
+	}
+	if got := res.(interface{ Unwrap() sspvo.Response }).Unwrap(); got != inner {
+		t.Errorf("Unwrap() = %v, want %v", got, inner)
+	}
+}
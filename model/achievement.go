@@ -0,0 +1,247 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"encoding/xml"
+	"sort"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//DefaultMaxAchievementPoints Maximum sum of the points for the achievements of the application in the campaign.
+const DefaultMaxAchievementPoints = 10
+
+//Achievement Achievement accounted in the campaign, datatype message.DatatypeAchievements.
+type Achievement struct {
+	XMLName     xml.Name `xml:"Achievement"`
+	UID         string   `xml:"UID"`
+	UIDCampaign string   `xml:"UIDCampaign"`
+	IDCategory  int      `xml:"IdCategory"`
+	Name        string   `xml:"Name"`
+	MaxValue    int      `xml:"MaxValue"`
+}
+
+//AppAchievement Achievement of the application, datatype message.DatatypeAppAchievements.
+type AppAchievement struct {
+	XMLName        xml.Name `xml:"AppAchievement"`
+	UID            string   `xml:"UID"`
+	UIDApplication string   `xml:"UIDApplication"`
+	UIDAchievement string   `xml:"UIDAchievement"`
+	UIDDocument    string   `xml:"UIDDocument,omitempty"`
+	Value          int      `xml:"Value"`
+}
+
+type achievementOptions struct {
+	maxPoints int
+}
+
+//AchievementOption Option of AchievementBuilder.
+type AchievementOption func(*achievementOptions)
+
+//SetMaxAchievementPoints To set the maximum sum of the points of the application, by default DefaultMaxAchievementPoints.
+func SetMaxAchievementPoints(points int) AchievementOption {
+	return func(o *achievementOptions) {
+		o.maxPoints = points
+	}
+}
+
+//AchievementBuilder Builder of the achievements of the campaign checking them against CLSAchievementCategory
+//and the achievements of the applications, calculates the points of the applications.
+type AchievementBuilder struct {
+	categories   *Classifier
+	campaign     Campaign
+	achievements map[string]Achievement
+	order        []string
+	options      achievementOptions
+}
+
+//NewAchievementBuilder Creating a new AchievementBuilder of the campaign for the classifier CLSAchievementCategory,
+//supports the following options: SetMaxAchievementPoints.
+func NewAchievementBuilder(categories *Classifier, campaign Campaign, opts ...AchievementOption) *AchievementBuilder {
+	options := achievementOptions{maxPoints: DefaultMaxAchievementPoints}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &AchievementBuilder{
+		categories:   categories,
+		campaign:     campaign,
+		achievements: map[string]Achievement{},
+		options:      options,
+	}
+}
+
+//LoadAchievementBuilder Creating a new AchievementBuilder for the classifier received from the service.
+func LoadAchievementBuilder(ctx context.Context, client sspvo.Client, campaign Campaign,
+	opts ...AchievementOption) (*AchievementBuilder, error) {
+	categories, err := LoadClassifier(ctx, client, message.CLSAchievementCategory)
+	if err != nil {
+		return nil, err
+	}
+	return NewAchievementBuilder(categories, campaign, opts...), nil
+}
+
+//Validate Check the achievement: the campaign is the campaign of the builder, the category is actual
+//and of the type of the campaign, the maximum points do not exceed the maximum of the application.
+func (b *AchievementBuilder) Validate(a Achievement) error {
+	if a.UID == "" {
+		return invalidf("achievement: empty UID")
+	}
+	if a.UIDCampaign != b.campaign.UID {
+		return invalidf("achievement %s: campaign %s, want %s", a.UID, a.UIDCampaign, b.campaign.UID)
+	}
+	if a.Name == "" {
+		return invalidf("achievement %s: empty Name", a.UID)
+	}
+	category, ok := b.categories.Item(a.IDCategory)
+	if !ok || !category.Actual {
+		return invalidf("achievement %s: category %d not found", a.UID, a.IDCategory)
+	}
+	if campaignType := category.Int("IDCampaignType"); campaignType != 0 && b.campaign.IDCampaignType != 0 &&
+		campaignType != b.campaign.IDCampaignType {
+		return invalidf("achievement %s: category %d is for campaign type %d, want %d", a.UID, a.IDCategory,
+			campaignType, b.campaign.IDCampaignType)
+	}
+	if a.MaxValue <= 0 || a.MaxValue > b.options.maxPoints {
+		return invalidf("achievement %s: max value %d out of range 1-%d", a.UID, a.MaxValue, b.options.maxPoints)
+	}
+	return nil
+}
+
+//Add Add the achievements of the campaign, the achievement with the known UID is replaced.
+func (b *AchievementBuilder) Add(achievements ...Achievement) error {
+	for _, a := range achievements {
+		if err := b.Validate(a); err != nil {
+			return err
+		}
+	}
+	for _, a := range achievements {
+		if _, ok := b.achievements[a.UID]; !ok {
+			b.order = append(b.order, a.UID)
+		}
+		b.achievements[a.UID] = a
+	}
+	return nil
+}
+
+//Achievements Receive the achievements of the campaign in the order of adding.
+func (b *AchievementBuilder) Achievements() []Achievement {
+	res := make([]Achievement, 0, len(b.order))
+	for _, uid := range b.order {
+		res = append(res, b.achievements[uid])
+	}
+	return res
+}
+
+//ValidateApp Check the achievement of the application: the achievement is added, the value is within its maximum.
+func (b *AchievementBuilder) ValidateApp(app AppAchievement) error {
+	if app.UID == "" {
+		return invalidf("app achievement: empty UID")
+	}
+	if app.UIDApplication == "" {
+		return invalidf("app achievement %s: empty UIDApplication", app.UID)
+	}
+	a, ok := b.achievements[app.UIDAchievement]
+	if !ok {
+		return invalidf("app achievement %s: achievement %s not found", app.UID, app.UIDAchievement)
+	}
+	if app.Value < 0 || app.Value > a.MaxValue {
+		return invalidf("app achievement %s: value %d out of range 0-%d", app.UID, app.Value, a.MaxValue)
+	}
+	return nil
+}
+
+//Points Calculate the points of the applications by UID. The achievement is counted once with the greatest value,
+//the sum is limited by SetMaxAchievementPoints.
+func (b *AchievementBuilder) Points(apps ...AppAchievement) (map[string]int, error) {
+	best := map[string]map[string]int{}
+	uids := map[string]bool{}
+	for _, app := range apps {
+		if err := b.ValidateApp(app); err != nil {
+			return nil, err
+		}
+		if uids[app.UID] {
+			return nil, invalidf("app achievement %s: duplicated", app.UID)
+		}
+		uids[app.UID] = true
+		if best[app.UIDApplication] == nil {
+			best[app.UIDApplication] = map[string]int{}
+		}
+		if app.Value > best[app.UIDApplication][app.UIDAchievement] {
+			best[app.UIDApplication][app.UIDAchievement] = app.Value
+		}
+	}
+	points := map[string]int{}
+	for uid, values := range best {
+		sum := 0
+		for _, v := range values {
+			sum += v
+		}
+		if sum > b.options.maxPoints {
+			sum = b.options.maxPoints
+		}
+		points[uid] = sum
+	}
+	return points, nil
+}
+
+//ApplyPoints Set RatingApplication.AchievementPoints of the applications by the achievements of the applications.
+func (b *AchievementBuilder) ApplyPoints(ratings []RatingApplication, apps ...AppAchievement) error {
+	points, err := b.Points(apps...)
+	if err != nil {
+		return err
+	}
+	for i := range ratings {
+		ratings[i].AchievementPoints = points[ratings[i].UID]
+	}
+	return nil
+}
+
+//Step Receive the step of the action with the achievements of the campaign.
+func (b *AchievementBuilder) Step(action message.Action) (Step, error) {
+	if len(b.order) == 0 {
+		return Step{}, invalidf("%s: records are empty", message.DatatypeAchievements)
+	}
+	records := make([]interface{}, 0, len(b.order))
+	for _, a := range b.Achievements() {
+		records = append(records, a)
+	}
+	data, err := packageData(records...)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Action: action, Datatype: message.DatatypeAchievements, Data: data}, nil
+}
+
+//AppStep Receive the step of the action with the achievements of the applications ordered by the application and UID.
+func (b *AchievementBuilder) AppStep(action message.Action, apps ...AppAchievement) (Step, error) {
+	if len(apps) == 0 {
+		return Step{}, invalidf("%s: records are empty", message.DatatypeAppAchievements)
+	}
+	if _, err := b.Points(apps...); err != nil {
+		return Step{}, err
+	}
+	sorted := append([]AppAchievement(nil), apps...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].UIDApplication != sorted[j].UIDApplication {
+			return sorted[i].UIDApplication < sorted[j].UIDApplication
+		}
+		return sorted[i].UID < sorted[j].UID
+	})
+	records := make([]interface{}, 0, len(sorted))
+	for _, app := range sorted {
+		records = append(records, app)
+	}
+	data, err := packageData(records...)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Action: action, Datatype: message.DatatypeAppAchievements, Data: data}, nil
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestAchievementBuilder_Validate(t *testing.T) {
+	categories := testClassifier(t, message.CLSAchievementCategory)
+	b := NewAchievementBuilder(categories, Campaign{UID: "C1", IDCampaignType: 1})
+	achievement := Achievement{UID: "AC1", UIDCampaign: "C1", IDCategory: 2, Name: "ГТО", MaxValue: 2}
+
+	tests := []struct {
+		name    string
+		update  func(a *Achievement)
+		wantErr bool
+	}{
+		{name: "ok", update: func(a *Achievement) {}},
+		{name: "fail uid", update: func(a *Achievement) { a.UID = "" }, wantErr: true},
+		{name: "fail campaign", update: func(a *Achievement) { a.UIDCampaign = "C2" }, wantErr: true},
+		{name: "fail name", update: func(a *Achievement) { a.Name = "" }, wantErr: true},
+		{name: "fail category", update: func(a *Achievement) { a.IDCategory = 1000 }, wantErr: true},
+		{name: "fail max value", update: func(a *Achievement) { a.MaxValue = 0 }, wantErr: true},
+		{name: "fail max value over cap", update: func(a *Achievement) { a.MaxValue = 11 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := achievement
+			tt.update(&a)
+			err := b.Validate(a)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	other := NewAchievementBuilder(categories, Campaign{UID: "C1", IDCampaignType: 2})
+	if err := other.Validate(achievement); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Validate() of other campaign type error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestAchievementBuilder_Points(t *testing.T) {
+	categories := testClassifier(t, message.CLSAchievementCategory)
+	b := NewAchievementBuilder(categories, Campaign{UID: "C1", IDCampaignType: 1}, SetMaxAchievementPoints(8))
+	if err := b.Add(
+		Achievement{UID: "AC1", UIDCampaign: "C1", IDCategory: 2, Name: "ГТО", MaxValue: 2},
+		Achievement{UID: "AC2", UIDCampaign: "C1", IDCategory: 3, Name: "Аттестат с отличием", MaxValue: 5},
+		Achievement{UID: "AC3", UIDCampaign: "C1", IDCategory: 1, Name: "Олимпийские игры", MaxValue: 4},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		apps    []AppAchievement
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name: "sum",
+			apps: []AppAchievement{
+				{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC1", Value: 2},
+				{UID: "P2", UIDApplication: "A1", UIDAchievement: "AC2", Value: 5},
+				{UID: "P3", UIDApplication: "A2", UIDAchievement: "AC2", Value: 3},
+			},
+			want: map[string]int{"A1": 7, "A2": 3},
+		},
+		{
+			name: "cap",
+			apps: []AppAchievement{
+				{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC1", Value: 2},
+				{UID: "P2", UIDApplication: "A1", UIDAchievement: "AC2", Value: 5},
+				{UID: "P3", UIDApplication: "A1", UIDAchievement: "AC3", Value: 4},
+			},
+			want: map[string]int{"A1": 8},
+		},
+		{
+			name: "achievement once",
+			apps: []AppAchievement{
+				{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC2", Value: 3},
+				{UID: "P2", UIDApplication: "A1", UIDAchievement: "AC2", Value: 4},
+			},
+			want: map[string]int{"A1": 4},
+		},
+		{name: "fail unknown", apps: []AppAchievement{{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC4", Value: 1}}, wantErr: true},
+		{name: "fail value", apps: []AppAchievement{{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC1", Value: 3}}, wantErr: true},
+		{name: "fail application", apps: []AppAchievement{{UID: "P1", UIDAchievement: "AC1", Value: 1}}, wantErr: true},
+		{
+			name: "fail duplicated",
+			apps: []AppAchievement{
+				{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC1", Value: 1},
+				{UID: "P1", UIDApplication: "A2", UIDAchievement: "AC1", Value: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.Points(tt.apps...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Points() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Points() = %v, want %v", got, tt.want)
+			}
+			for uid, points := range tt.want {
+				if got[uid] != points {
+					t.Errorf("Points()[%s] = %d, want %d", uid, got[uid], points)
+				}
+			}
+		})
+	}
+
+	ratings := []RatingApplication{{UID: "A1"}, {UID: "A2", AchievementPoints: 3}}
+	if err := b.ApplyPoints(ratings, AppAchievement{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC2", Value: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if ratings[0].AchievementPoints != 5 || ratings[1].AchievementPoints != 0 {
+		t.Errorf("ApplyPoints() = %+v", ratings)
+	}
+}
+
+func TestAchievementBuilder_Step(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	b, err := LoadAchievementBuilder(context.Background(), ts.Client, Campaign{UID: "C1", IDCampaignType: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = b.Step(message.ActionAdd); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Step() of empty error = %v, want %v", err, ErrInvalid)
+	}
+	achievement := Achievement{UID: "AC1", UIDCampaign: "C1", IDCategory: 2, Name: "ГТО", MaxValue: 2}
+	if err := b.Add(achievement); err != nil {
+		t.Fatal(err)
+	}
+	achievement.MaxValue = 3
+	if err := b.Add(achievement); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Achievements(); len(got) != 1 || got[0].MaxValue != 3 {
+		t.Errorf("Achievements() = %+v", got)
+	}
+
+	opt := SetPollInterval(time.Millisecond)
+	for _, step := range []Step{
+		{Action: message.ActionAdd, Datatype: message.DatatypeCampaign, Data: []byte("<PackageData><Campaign><UID>C1</UID></Campaign></PackageData>")},
+		func() Step { s, _ := b.Step(message.ActionAdd); return s }(),
+	} {
+		if _, err := Exchange(context.Background(), ts.Client, ts.Crypto, step, opt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := Exchange(context.Background(), ts.Client, ts.Crypto, Step{Action: message.ActionGet, Datatype: message.DatatypeAchievements,
+		Data: []byte("<PackageData><Achievement><UID>AC1</UID></Achievement></PackageData>")}, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := info.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records.Achievements) != 1 || records.Achievements[0].MaxValue != 3 || records.Achievements[0].IDCategory != 2 {
+		t.Errorf("Records() = %+v", records.Achievements)
+	}
+
+	step, err := b.AppStep(message.ActionAdd,
+		AppAchievement{UID: "P2", UIDApplication: "A2", UIDAchievement: "AC1", Value: 1},
+		AppAchievement{UID: "P1", UIDApplication: "A1", UIDAchievement: "AC1", Value: 2},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps, err := DecodeRecords(step.Datatype, step.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps.AppAchievements) != 2 || apps.AppAchievements[0].UID != "P1" {
+		t.Errorf("AppStep() = %s", step.Data)
+	}
+	if _, err = Exchange(context.Background(), ts.Client, ts.Crypto, step, opt); !errors.Is(err, ErrRejected) {
+		t.Errorf("Exchange() of unknown applications error = %v, want %v", err, ErrRejected)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//Application Application of the entrant to the competitive group, datatype message.DatatypeApplications.
+type Application struct {
+	XMLName             xml.Name `xml:"Application"`
+	UID                 string   `xml:"UID"`
+	UIDEpgu             string   `xml:"UIDEpgu,omitempty"`
+	GUIDEntrant         string   `xml:"GUIDEntrant"`
+	UIDCompetitiveGroup string   `xml:"UIDCompetitiveGroup"`
+	AppNumber           string   `xml:"AppNumber,omitempty"`
+	RegistrationDate    Date     `xml:"RegistrationDate"`
+	IDStatus            int      `xml:"IdStatus"`
+	Priority            int      `xml:"Priority,omitempty"`
+	NeedHostel          bool     `xml:"NeedHostel"`
+}
+
+//ApplicationStatusChange Record of the datatype message.DatatypeEditApplicationStatus,
+//the application is identified by UIDEpgu or by UID.
+type ApplicationStatusChange struct {
+	XMLName       xml.Name `xml:"Application"`
+	UID           string   `xml:"UID,omitempty"`
+	UIDEpgu       string   `xml:"UIDEpgu,omitempty"`
+	IDStatus      int      `xml:"IdStatus"`
+	StatusComment string   `xml:"StatusComment,omitempty"`
+}
+
+//key Receive the key of the application of the change.
+func (c ApplicationStatusChange) key() string {
+	if c.UIDEpgu != "" {
+		return c.UIDEpgu
+	}
+	return c.UID
+}
+
+type workflowOptions struct {
+	transitions map[int][]int
+}
+
+type WorkflowOption func(*workflowOptions)
+
+//SetTransitions To set the allowed transitions between the IDs of the statuses of CLSApplicationStatuses,
+//the classifier of the service has no transitions so the option is required.
+func SetTransitions(transitions map[int][]int) WorkflowOption {
+	return func(o *workflowOptions) {
+		o.transitions = transitions
+	}
+}
+
+//ApplicationWorkflow Transitions of the applications between the statuses of CLSApplicationStatuses,
+//the status of the application is changed after the service confirms the message.
+type ApplicationWorkflow struct {
+	statuses    *Classifier
+	transitions map[int]map[int]bool
+
+	mu        sync.Mutex
+	confirmed map[string]int
+	pending   map[int]ApplicationStatusChange
+}
+
+//NewApplicationWorkflow Creating a new ApplicationWorkflow for the statuses of the classifier CLSApplicationStatuses,
+//supports the following options: SetTransitions.
+func NewApplicationWorkflow(statuses *Classifier, opts ...WorkflowOption) (*ApplicationWorkflow, error) {
+	o := workflowOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if len(o.transitions) == 0 {
+		return nil, errors.New("model: workflow: transitions not set")
+	}
+
+	w := &ApplicationWorkflow{
+		statuses:    statuses,
+		transitions: map[int]map[int]bool{},
+		confirmed:   map[string]int{},
+		pending:     map[int]ApplicationStatusChange{},
+	}
+	for from, toIDs := range o.transitions {
+		if _, ok := statuses.Item(from); !ok {
+			return nil, fmt.Errorf("model: workflow: status %d not found in %s", from, statuses.CLS)
+		}
+		w.transitions[from] = map[int]bool{}
+		for _, to := range toIDs {
+			if _, ok := statuses.Item(to); !ok {
+				return nil, fmt.Errorf("model: workflow: status %d not found in %s", to, statuses.CLS)
+			}
+			w.transitions[from][to] = true
+		}
+	}
+	return w, nil
+}
+
+//LoadApplicationWorkflow Creating a new ApplicationWorkflow for the classifier CLSApplicationStatuses received from the service.
+func LoadApplicationWorkflow(ctx context.Context, client sspvo.Client, opts ...WorkflowOption) (*ApplicationWorkflow, error) {
+	statuses, err := LoadClassifier(ctx, client, message.CLSApplicationStatuses)
+	if err != nil {
+		return nil, err
+	}
+	return NewApplicationWorkflow(statuses, opts...)
+}
+
+//Status Receive the status by its code.
+func (w *ApplicationWorkflow) Status(code string) (ClassifierItem, bool) {
+	return w.statuses.ItemByCode(code)
+}
+
+//Validate Check the transition between the statuses, the status must be actual.
+func (w *ApplicationWorkflow) Validate(from, to int) error {
+	toStatus, ok := w.statuses.Item(to)
+	if !ok || !toStatus.Actual {
+		return invalidf("application status %d is unknown or not actual", to)
+	}
+	fromStatus, ok := w.statuses.Item(from)
+	if !ok {
+		return invalidf("application status %d is unknown", from)
+	}
+	if !w.transitions[from][to] {
+		return invalidf("application status can not be changed from %d %q to %d %q", from, fromStatus.Name, to, toStatus.Name)
+	}
+	return nil
+}
+
+//SetConfirmed Set the status of the application known to the service, for example loaded by ActionGet.
+func (w *ApplicationWorkflow) SetConfirmed(key string, status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.confirmed[key] = status
+}
+
+//Confirmed Receive the status of the application confirmed by the service, the key is UIDEpgu or UID.
+func (w *ApplicationWorkflow) Confirmed(key string) (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	status, ok := w.confirmed[key]
+	return status, ok
+}
+
+//Transit Receive the step changing the status of the application, the transition is validated from the confirmed status.
+//For the application without the confirmed status only the target status is checked.
+func (w *ApplicationWorkflow) Transit(change ApplicationStatusChange) (Step, error) {
+	key := change.key()
+	if key == "" {
+		return Step{}, invalidf("application status: UIDEpgu and UID are empty")
+	}
+	if from, ok := w.Confirmed(key); ok {
+		if err := w.Validate(from, change.IDStatus); err != nil {
+			return Step{}, fmt.Errorf("%w: application %s", err, key)
+		}
+	} else if status, ok := w.statuses.Item(change.IDStatus); !ok || !status.Actual {
+		return Step{}, invalidf("application %s: status %d is unknown or not actual", key, change.IDStatus)
+	}
+
+	data, err := packageData(change)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Action: message.ActionEdit, Datatype: message.DatatypeEditApplicationStatus, Data: data}, nil
+}
+
+//Sent Remember the change sent by the message, the status is confirmed by Track.
+func (w *ApplicationWorkflow) Sent(idJWT int, change ApplicationStatusChange) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending[idJWT] = change
+}
+
+//Send the change of the status and remember it until Track.
+func (w *ApplicationWorkflow) Send(ctx context.Context, client sspvo.Client, crypto sspvo.Crypto, change ApplicationStatusChange) (int, error) {
+	step, err := w.Transit(change)
+	if err != nil {
+		return 0, err
+	}
+	data, err := client.Send(ctx, step.Message(crypto)).Data()
+	if err != nil {
+		return 0, fmt.Errorf("model: application %s: %w", change.key(), err)
+	}
+	idJWT, err := newMessageIDJWT(data)
+	if err != nil {
+		return 0, err
+	}
+	w.Sent(idJWT, change)
+	return idJWT, nil
+}
+
+//Pending Receive the sorted IDJWT of the messages waiting for the result.
+func (w *ApplicationWorkflow) Pending() []int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ids := make([]int, 0, len(w.pending))
+	for idJWT := range w.pending {
+		ids = append(ids, idJWT)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+//Track Apply the result of the message received from the queue: the status of the application is confirmed,
+//or the error wrapping ErrRejected is returned. The results of the other messages are ignored, tracked is false.
+func (w *ApplicationWorkflow) Track(info *Info) (tracked bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	change, ok := w.pending[info.IDJWT]
+	if !ok {
+		return false, nil
+	}
+	delete(w.pending, info.IDJWT)
+	if err = info.Err(); err != nil {
+		return true, fmt.Errorf("%w: application %s", err, change.key())
+	}
+	w.confirmed[change.key()] = change.IDStatus
+	return true, nil
+}
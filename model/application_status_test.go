@@ -0,0 +1,180 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+//applicationTransitions Transitions between the statuses of the fixture ApplicationStatuses of the test server.
+var applicationTransitions = SetTransitions(map[int][]int{
+	1: {2, 7},
+	2: {3, 4, 6, 7},
+	3: {2, 7},
+	4: {5, 6, 7},
+	5: {8, 7},
+	8: {4, 7},
+})
+
+func TestNewApplicationWorkflow(t *testing.T) {
+	statuses := testClassifier(t, message.CLSApplicationStatuses)
+
+	tests := []struct {
+		name    string
+		opts    []WorkflowOption
+		wantErr bool
+	}{
+		{name: "ok", opts: []WorkflowOption{applicationTransitions}},
+		{name: "fail transitions not set", wantErr: true},
+		{name: "fail unknown from", opts: []WorkflowOption{SetTransitions(map[int][]int{100: {2}})}, wantErr: true},
+		{name: "fail unknown to", opts: []WorkflowOption{SetTransitions(map[int][]int{1: {100}})}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewApplicationWorkflow(statuses, tt.opts...); (err != nil) != tt.wantErr {
+				t.Errorf("NewApplicationWorkflow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplicationWorkflow_Validate(t *testing.T) {
+	statuses := testClassifier(t, message.CLSApplicationStatuses)
+	statuses.Items[7].Actual = false
+	w, err := NewApplicationWorkflow(statuses, applicationTransitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		from    int
+		to      int
+		wantErr bool
+	}{
+		{name: "ok new to in_work", from: 1, to: 2},
+		{name: "ok competition to enrolled", from: 4, to: 5},
+		{name: "fail new to enrolled", from: 1, to: 5, wantErr: true},
+		{name: "fail revoked is final", from: 7, to: 2, wantErr: true},
+		{name: "fail not actual", from: 5, to: 8, wantErr: true},
+		{name: "fail unknown", from: 100, to: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := w.Validate(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestApplicationWorkflow_Transit(t *testing.T) {
+	statuses := testClassifier(t, message.CLSApplicationStatuses)
+	w, err := NewApplicationWorkflow(statuses, applicationTransitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.SetConfirmed("865633484", 1)
+
+	tests := []struct {
+		name    string
+		change  ApplicationStatusChange
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "ok",
+			change: ApplicationStatusChange{UIDEpgu: "865633484", IDStatus: 2, StatusComment: "Принято"},
+			want: "<PackageData><Application><UIDEpgu>865633484</UIDEpgu><IdStatus>2</IdStatus>" +
+				"<StatusComment>Принято</StatusComment></Application></PackageData>",
+		},
+		{
+			name:   "ok not confirmed",
+			change: ApplicationStatusChange{UID: "A2", IDStatus: 4},
+			want:   "<PackageData><Application><UID>A2</UID><IdStatus>4</IdStatus></Application></PackageData>",
+		},
+		{name: "fail transition", change: ApplicationStatusChange{UIDEpgu: "865633484", IDStatus: 5}, wantErr: true},
+		{name: "fail unknown status", change: ApplicationStatusChange{UID: "A2", IDStatus: 100}, wantErr: true},
+		{name: "fail key", change: ApplicationStatusChange{IDStatus: 2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, err := w.Transit(tt.change)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Transit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Transit() error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if step.Action != message.ActionEdit || step.Datatype != message.DatatypeEditApplicationStatus || string(step.Data) != tt.want {
+				t.Errorf("Transit() = %v %v %s, want %s", step.Action, step.Datatype, step.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplicationWorkflow_Track(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+
+	w, err := LoadApplicationWorkflow(ctx, ts.Client, applicationTransitions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.SetConfirmed("A1", 1)
+
+	idJWT, err := w.Send(ctx, ts.Client, ts.Crypto, ApplicationStatusChange{UID: "A1", IDStatus: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending := w.Pending(); len(pending) != 1 || pending[0] != idJWT {
+		t.Fatalf("Pending() = %v", pending)
+	}
+	if status, _ := w.Confirmed("A1"); status != 1 {
+		t.Errorf("Confirmed() before Track = %d, want 1", status)
+	}
+
+	data, err := ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, idJWT)).Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := ParseInfo(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tracked, err := w.Track(info); !tracked || err != nil {
+		t.Fatalf("Track() = %v, %v", tracked, err)
+	}
+	if status, _ := w.Confirmed("A1"); status != 2 {
+		t.Errorf("Confirmed() = %d, want 2", status)
+	}
+	if tracked, _ := w.Track(info); tracked {
+		t.Errorf("Track() of the tracked message must be ignored")
+	}
+
+	w.Sent(100, ApplicationStatusChange{UID: "A1", IDStatus: 4})
+	tracked, err := w.Track(&Info{IDJWT: 100, Errors: []InfoError{{Code: "4056", Description: "Заявление не найдено."}}})
+	if !tracked || !errors.Is(err, ErrRejected) {
+		t.Errorf("Track() = %v, %v, want %v", tracked, err, ErrRejected)
+	}
+	if status, _ := w.Confirmed("A1"); status != 2 {
+		t.Errorf("Confirmed() after rejection = %d, want 2", status)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/xml"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//SubdivisionOrg Subdivision of the organization, datatype message.DatatypeSubdivisionOrg.
+type SubdivisionOrg struct {
+	XMLName xml.Name `xml:"SubdivisionOrg"`
+	UID     string   `xml:"UID"`
+	Name    string   `xml:"Name"`
+}
+
+//Campaign Admission campaign, datatype message.DatatypeCampaign.
+type Campaign struct {
+	XMLName          xml.Name `xml:"Campaign"`
+	UID              string   `xml:"UID"`
+	Name             string   `xml:"Name"`
+	YearStart        int      `xml:"YearStart"`
+	YearEnd          int      `xml:"YearEnd"`
+	IDCampaignType   int      `xml:"IdCampaignType"`
+	IDCampaignStatus int      `xml:"IdCampaignStatus"`
+	EndDate          Date     `xml:"EndDate"`
+}
+
+//AdmissionVolume Admission volume of the campaign by the direction, datatype message.DatatypeAdmissionVolume.
+type AdmissionVolume struct {
+	XMLName          xml.Name `xml:"AdmissionVolume"`
+	UID              string   `xml:"UID"`
+	UIDCampaign      string   `xml:"UIDCampaign"`
+	IDDirection      int      `xml:"IdDirection"`
+	IDEducationLevel int      `xml:"IdEducationLevel"`
+	BudgetO          int      `xml:"BudgetO"`
+	BudgetOZ         int      `xml:"BudgetOZ"`
+	BudgetZ          int      `xml:"BudgetZ"`
+	QuotaO           int      `xml:"QuotaO"`
+	QuotaOZ          int      `xml:"QuotaOZ"`
+	QuotaZ           int      `xml:"QuotaZ"`
+	PaidO            int      `xml:"PaidO"`
+	PaidOZ           int      `xml:"PaidOZ"`
+	PaidZ            int      `xml:"PaidZ"`
+}
+
+//DistributedAdmissionVolume Admission volume distributed by the level of the budget, datatype message.DatatypeDistributedAdmissionVolume.
+type DistributedAdmissionVolume struct {
+	XMLName            xml.Name `xml:"DistributedAdmissionVolume"`
+	UID                string   `xml:"UID"`
+	UIDAdmissionVolume string   `xml:"UIDAdmissionVolume"`
+	IDLevelBudget      int      `xml:"IdLevelBudget"`
+	BudgetO            int      `xml:"BudgetO"`
+	BudgetOZ           int      `xml:"BudgetOZ"`
+	BudgetZ            int      `xml:"BudgetZ"`
+	QuotaO             int      `xml:"QuotaO"`
+	QuotaOZ            int      `xml:"QuotaOZ"`
+	QuotaZ             int      `xml:"QuotaZ"`
+}
+
+//CampaignState State of the campaign with the records of the datatypes of CampaignDatatypes.
+//The empty references to the campaign are set from the campaign, the state without the campaign UID has only the subdivisions.
+type CampaignState struct {
+	Campaign          Campaign
+	Subdivisions      []SubdivisionOrg
+	AdmissionVolumes  []AdmissionVolume
+	CompetitiveGroups []CompetitiveGroup
+}
+
+//CampaignDatatypes Datatypes of the campaign in the order of the dependencies.
+var CampaignDatatypes = append([]message.Datatype{
+	message.DatatypeSubdivisionOrg,
+	message.DatatypeCampaign,
+	message.DatatypeAdmissionVolume,
+}, CompetitiveGroupDatatypes...)
+
+//Validate Check the campaign and its records before sending.
+func (s *CampaignState) Validate() error {
+	if s.Campaign.UID == "" {
+		return invalidf("campaign: UID is empty")
+	}
+	_, err := s.records()
+	return err
+}
+
+//records Receive the records of the state by CampaignDatatypes.
+func (s *CampaignState) records() ([]keyedGroup, error) {
+	groups := make([]keyedGroup, len(CampaignDatatypes))
+	for i, datatype := range CampaignDatatypes {
+		groups[i].datatype = datatype
+	}
+	if s == nil {
+		return groups, nil
+	}
+
+	uid := s.Campaign.UID
+	switch {
+	case uid != "":
+		groups[1].records = []keyedRecord{{uid: uid, record: s.Campaign}}
+	case len(s.AdmissionVolumes) > 0 || len(s.CompetitiveGroups) > 0:
+		return nil, invalidf("campaign: UID is empty")
+	}
+
+	for _, subdivision := range s.Subdivisions {
+		groups[0].records = append(groups[0].records, keyedRecord{uid: subdivision.UID, record: subdivision})
+	}
+	for _, volume := range s.AdmissionVolumes {
+		parent := volume.UIDCampaign
+		volume.UIDCampaign = uid
+		groups[2].records = append(groups[2].records, keyedRecord{uid: volume.UID, parent: parent, wantParent: uid, record: volume})
+	}
+	for i := range s.CompetitiveGroups {
+		group := s.CompetitiveGroups[i]
+		if group.UIDCampaign != "" && group.UIDCampaign != uid {
+			return nil, invalidf("campaign %s: competitive group %s: belongs to %s", uid, group.UID, group.UIDCampaign)
+		}
+		group.UIDCampaign = uid
+		if err := group.Validate(); err != nil {
+			return nil, err
+		}
+		for j, records := range group.records() {
+			groups[3+j].records = append(groups[3+j].records, records.records...)
+		}
+	}
+
+	for _, group := range groups {
+		seen := map[string]bool{}
+		for _, r := range group.records {
+			if r.uid == "" {
+				return nil, invalidf("campaign %s: %s: UID is empty", uid, group.datatype)
+			}
+			if seen[r.uid] {
+				return nil, invalidf("campaign %s: %s %s: UID is duplicated", uid, group.datatype, r.uid)
+			}
+			seen[r.uid] = true
+			if r.parent != "" && r.parent != r.wantParent {
+				return nil, invalidf("campaign %s: %s %s: belongs to %s", uid, group.datatype, r.uid, r.parent)
+			}
+		}
+	}
+	return groups, nil
+}
+
+//DiffCampaigns Receive the minimal steps changing the campaign current to desired, the order is the same as DiffCompetitiveGroups.
+//The subdivisions are shared by the campaigns of the organization and are never removed,
+//the current state without the campaign or nil adds the campaign.
+func DiffCampaigns(current, desired *CampaignState) (Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+	next, err := desired.records()
+	if err != nil {
+		return nil, err
+	}
+	prev, err := current.records()
+	if err != nil {
+		return nil, err
+	}
+
+	subdivisions := map[string]bool{}
+	for _, r := range next[0].records {
+		subdivisions[r.uid] = true
+	}
+	var kept []keyedRecord
+	for _, r := range prev[0].records {
+		if subdivisions[r.uid] {
+			kept = append(kept, r)
+		}
+	}
+	prev[0].records = kept
+
+	return diffRecords(prev, next)
+}
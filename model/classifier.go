@@ -0,0 +1,114 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//ClassifierItem Record of the classifier, the elements other than ID, Code, Name and Actual are kept in Fields.
+type ClassifierItem struct {
+	ID     int
+	Code   string
+	Name   string
+	Actual bool
+	Fields map[string]string
+}
+
+//Int Receive the integer value of the field, 0 if it is absent or not a number.
+func (i ClassifierItem) Int(field string) int {
+	v, _ := strconv.Atoi(i.Fields[field])
+	return v
+}
+
+//Classifier Records of the classifier received by CLSMessage.
+type Classifier struct {
+	CLS   message.CLS
+	Items []ClassifierItem
+}
+
+type classifierField struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type classifierData struct {
+	Items []struct {
+		Fields []classifierField `xml:",any"`
+	} `xml:",any"`
+}
+
+//ParseClassifier Read the XML of the classifier, the record without Actual is actual.
+func ParseClassifier(cls message.CLS, data []byte) (*Classifier, error) {
+	raw := classifierData{}
+	if err := xml.Unmarshal(bytes.TrimSpace(data), &raw); err != nil {
+		return nil, fmt.Errorf("model: classifier %s: %w", cls, err)
+	}
+	c := &Classifier{CLS: cls}
+	for n, rawItem := range raw.Items {
+		item := ClassifierItem{Actual: true, Fields: map[string]string{}}
+		for _, f := range rawItem.Fields {
+			value := strings.TrimSpace(f.Value)
+			switch f.XMLName.Local {
+			case "ID":
+				id, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("model: classifier %s: record %d: %w", cls, n+1, err)
+				}
+				item.ID = id
+			case "Code":
+				item.Code = value
+			case "Name":
+				item.Name = value
+			case "Actual":
+				item.Actual = value == "true" || value == "1"
+			default:
+				item.Fields[f.XMLName.Local] = value
+			}
+		}
+		c.Items = append(c.Items, item)
+	}
+	return c, nil
+}
+
+//LoadClassifier Receive the classifier from the service.
+func LoadClassifier(ctx context.Context, client sspvo.Client, cls message.CLS) (*Classifier, error) {
+	data, err := client.Send(ctx, message.NewCLSMessage(cls)).Data()
+	if err != nil {
+		return nil, fmt.Errorf("model: classifier %s: %w", cls, err)
+	}
+	return ParseClassifier(cls, data)
+}
+
+//Item Receive the record by ID.
+func (c *Classifier) Item(id int) (ClassifierItem, bool) {
+	for _, item := range c.Items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return ClassifierItem{}, false
+}
+
+//ItemByCode Receive the record by Code.
+func (c *Classifier) ItemByCode(code string) (ClassifierItem, bool) {
+	for _, item := range c.Items {
+		if item.Code == code {
+			return item, true
+		}
+	}
+	return ClassifierItem{}, false
+}
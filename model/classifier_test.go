@@ -0,0 +1,119 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+//testClassifier Receive the classifier from the fixture of test_server_epgu.
+func testClassifier(t *testing.T, cls message.CLS) *Classifier {
+	t.Helper()
+	data, ok := test_server_epgu.ClsFixture(cls)
+	if !ok {
+		t.Fatalf("no fixture of the classifier %s", cls)
+	}
+	c, err := ParseClassifier(cls, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestParseClassifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []ClassifierItem
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			data: `<?xml version="1.0" encoding="UTF-8"?><MinScoreSubjects>
+<MinScore><ID>1</ID><IDSubject>1</IDSubject><MinScore>36</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>2</ID><Code>x</Code><Name>Старый</Name><Actual>false</Actual></MinScore>
+<MinScore><ID>3</ID></MinScore>
+</MinScoreSubjects>`,
+			want: []ClassifierItem{
+				{ID: 1, Actual: true, Fields: map[string]string{"IDSubject": "1", "MinScore": "36"}},
+				{ID: 2, Code: "x", Name: "Старый", Fields: map[string]string{}},
+				{ID: 3, Actual: true, Fields: map[string]string{}},
+			},
+		},
+		{name: "fail id", data: "<Genders><Gender><ID>x</ID></Gender></Genders>", wantErr: true},
+		{name: "fail xml", data: "<Genders>", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClassifier(message.CLSMinScoreSubjects, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseClassifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.Items, tt.want) {
+				t.Errorf("ParseClassifier() = %+v, want %+v", got.Items, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifier_Item(t *testing.T) {
+	statuses := testClassifier(t, message.CLSApplicationStatuses)
+
+	tests := []struct {
+		name   string
+		id     int
+		code   string
+		wantID int
+		wantOK bool
+	}{
+		{name: "ok id", id: 2, wantID: 2, wantOK: true},
+		{name: "ok code", code: "enrolled", wantID: 5, wantOK: true},
+		{name: "fail id", id: 100},
+		{name: "fail code", code: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				item ClassifierItem
+				ok   bool
+			)
+			if tt.code != "" {
+				item, ok = statuses.ItemByCode(tt.code)
+			} else {
+				item, ok = statuses.Item(tt.id)
+			}
+			if ok != tt.wantOK || item.ID != tt.wantID || (ok && !item.Actual) {
+				t.Errorf("Item() = %+v, %v, want %d %v", item, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLoadClassifier(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+
+	statuses, err := LoadClassifier(context.Background(), ts.Client, message.CLSApplicationStatuses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses.Items) != 8 || statuses.CLS != message.CLSApplicationStatuses {
+		t.Errorf("LoadClassifier() = %+v", statuses)
+	}
+
+	if _, err = LoadClassifier(context.Background(), ts.Client, "Unknown"); err == nil {
+		t.Errorf("LoadClassifier() of unknown classifier must fail")
+	}
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/xml"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//CompetitiveGroup Competitive group with the programs, benefits and entrance tests sent by the separate datatypes.
+type CompetitiveGroup struct {
+	XMLName           xml.Name `xml:"CompetitiveGroup"`
+	UID               string   `xml:"UID"`
+	UIDCampaign       string   `xml:"UIDCampaign"`
+	UIDSubdivisionOrg string   `xml:"UIDSubdivisionOrg,omitempty"`
+	Name              string   `xml:"Name"`
+	IDLevelBudget     int      `xml:"IdLevelBudget,omitempty"`
+	IDEducationLevel  int      `xml:"IdEducationLevel"`
+	IDEducationSource int      `xml:"IdEducationSource"`
+	IDEducationForm   int      `xml:"IdEducationForm"`
+	AdmissionNumber   int      `xml:"AdmissionNumber"`
+	Comment           string   `xml:"Comment,omitempty"`
+
+	Programs      []CompetitiveGroupProgram `xml:"-"`
+	Benefits      []CompetitiveBenefit      `xml:"-"`
+	EntranceTests []EntranceTest            `xml:"-"`
+}
+
+//CompetitiveGroupProgram Educational program of the group, datatype message.DatatypeCompetitiveGroupPrograms.
+type CompetitiveGroupProgram struct {
+	XMLName             xml.Name `xml:"CompetitiveGroupProgram"`
+	UID                 string   `xml:"UID"`
+	UIDCompetitiveGroup string   `xml:"UIDCompetitiveGroup"`
+	Name                string   `xml:"Name"`
+	IDDirection         int      `xml:"IdDirection,omitempty"`
+}
+
+//CompetitiveBenefit Benefit of the olympiad winners in the group, datatype message.DatatypeCompetitiveBenefits.
+type CompetitiveBenefit struct {
+	XMLName              xml.Name `xml:"CompetitiveBenefit"`
+	UID                  string   `xml:"UID"`
+	UIDCompetitiveGroup  string   `xml:"UIDCompetitiveGroup"`
+	IDBenefit            int      `xml:"IdBenefit"`
+	IDOlympicDiplomaType int      `xml:"IdOlympicDiplomaType,omitempty"`
+	IDOlympicLevel       int      `xml:"IdOlympicLevel,omitempty"`
+}
+
+//EntranceTest Entrance test of the group, datatype message.DatatypeEntranceTests.
+type EntranceTest struct {
+	XMLName             xml.Name `xml:"EntranceTest"`
+	UID                 string   `xml:"UID"`
+	UIDCompetitiveGroup string   `xml:"UIDCompetitiveGroup"`
+	IDEntranceTestType  int      `xml:"IdEntranceTestType"`
+	TestName            string   `xml:"TestName,omitempty"`
+	IDSubject           int      `xml:"IdSubject,omitempty"`
+	MinScore            int      `xml:"MinScore"`
+	Priority            int      `xml:"Priority"`
+
+	Benefits []EntranceTestBenefit `xml:"-"`
+}
+
+//EntranceTestBenefit Benefit replacing the result of the entrance test, datatype message.DatatypeEntranceTestBenefits.
+type EntranceTestBenefit struct {
+	XMLName              xml.Name `xml:"EntranceTestBenefit"`
+	UID                  string   `xml:"UID"`
+	UIDEntranceTest      string   `xml:"UIDEntranceTest"`
+	IDBenefit            int      `xml:"IdBenefit"`
+	IDOlympicDiplomaType int      `xml:"IdOlympicDiplomaType,omitempty"`
+	IDOlympicLevel       int      `xml:"IdOlympicLevel,omitempty"`
+}
+
+//CompetitiveGroupDatatypes Datatypes of the competitive group in the order of the dependencies.
+var CompetitiveGroupDatatypes = []message.Datatype{
+	message.DatatypeCompetitiveGroups,
+	message.DatatypeCompetitiveGroupPrograms,
+	message.DatatypeCompetitiveBenefits,
+	message.DatatypeEntranceTests,
+	message.DatatypeEntranceTestBenefits,
+}
+
+//Validate Check the group and its records before sending.
+func (g *CompetitiveGroup) Validate() error {
+	if g.UID == "" {
+		return invalidf("competitive group: UID is empty")
+	}
+	if g.UIDCampaign == "" {
+		return invalidf("competitive group %s: UIDCampaign is empty", g.UID)
+	}
+	for _, group := range g.records() {
+		seen := map[string]bool{}
+		for _, r := range group.records {
+			if r.uid == "" {
+				return invalidf("competitive group %s: %s: UID is empty", g.UID, group.datatype)
+			}
+			if seen[r.uid] {
+				return invalidf("competitive group %s: %s %s: UID is duplicated", g.UID, group.datatype, r.uid)
+			}
+			seen[r.uid] = true
+			if r.parent != "" && r.parent != r.wantParent {
+				return invalidf("competitive group %s: %s %s: belongs to %s", g.UID, group.datatype, r.uid, r.parent)
+			}
+		}
+	}
+	for _, test := range g.EntranceTests {
+		if test.MinScore < 0 || test.MinScore > 100 {
+			return invalidf("competitive group %s: entrance test %s: min score %d is out of 0-100", g.UID, test.UID, test.MinScore)
+		}
+	}
+	return nil
+}
+
+//Plan Receive the steps adding the group with all records.
+func (g *CompetitiveGroup) Plan() (Plan, error) {
+	return DiffCompetitiveGroups(nil, g)
+}
+
+//Diff Receive the minimal steps changing the previous version of the group to this one.
+func (g *CompetitiveGroup) Diff(prev *CompetitiveGroup) (Plan, error) {
+	return DiffCompetitiveGroups(prev, g)
+}
+
+//DiffCompetitiveGroups Receive the minimal steps changing the group prev to next: the removed records are removed first
+//from the dependent datatypes to the group, then the records are added and edited from the group to the dependent datatypes.
+//The nil prev adds the group, the nil next removes it. The group with the other UID is replaced entirely.
+//The empty references to the group and the entrance test are set from the parent record.
+func DiffCompetitiveGroups(prev, next *CompetitiveGroup) (Plan, error) {
+	if prev != nil {
+		if err := prev.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if next != nil {
+		if err := next.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if prev != nil && next != nil && prev.UID != next.UID {
+		remove, err := DiffCompetitiveGroups(prev, nil)
+		if err != nil {
+			return nil, err
+		}
+		add, err := DiffCompetitiveGroups(nil, next)
+		if err != nil {
+			return nil, err
+		}
+		return append(remove, add...), nil
+	}
+	return diffRecords(prev.records(), next.records())
+}
+
+//records Receive the records of the group by CompetitiveGroupDatatypes with the references set from the parent records.
+func (g *CompetitiveGroup) records() []keyedGroup {
+	groups := make([]keyedGroup, len(CompetitiveGroupDatatypes))
+	for i, datatype := range CompetitiveGroupDatatypes {
+		groups[i].datatype = datatype
+	}
+	if g == nil {
+		return groups
+	}
+
+	groups[0].records = append(groups[0].records, keyedRecord{uid: g.UID, record: g})
+	for _, program := range g.Programs {
+		parent := program.UIDCompetitiveGroup
+		program.UIDCompetitiveGroup = g.UID
+		groups[1].records = append(groups[1].records, keyedRecord{uid: program.UID, parent: parent, wantParent: g.UID, record: program})
+	}
+	for _, benefit := range g.Benefits {
+		parent := benefit.UIDCompetitiveGroup
+		benefit.UIDCompetitiveGroup = g.UID
+		groups[2].records = append(groups[2].records, keyedRecord{uid: benefit.UID, parent: parent, wantParent: g.UID, record: benefit})
+	}
+	for _, test := range g.EntranceTests {
+		parent := test.UIDCompetitiveGroup
+		test.UIDCompetitiveGroup = g.UID
+		groups[3].records = append(groups[3].records, keyedRecord{uid: test.UID, parent: parent, wantParent: g.UID, record: test})
+		for _, benefit := range test.Benefits {
+			parent := benefit.UIDEntranceTest
+			benefit.UIDEntranceTest = test.UID
+			groups[4].records = append(groups[4].records, keyedRecord{uid: benefit.UID, parent: parent, wantParent: test.UID, record: benefit})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestCompetitiveGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   CompetitiveGroup
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			group: CompetitiveGroup{
+				UID: "G1", UIDCampaign: "C1", Name: "Информатика, бюджет",
+				Programs: []CompetitiveGroupProgram{{UID: "P1", Name: "Программная инженерия"}},
+				Benefits: []CompetitiveBenefit{{UID: "B1", IDBenefit: 1}},
+				EntranceTests: []EntranceTest{
+					{UID: "T1", IDSubject: 2, MinScore: 39, Benefits: []EntranceTestBenefit{{UID: "TB1", IDBenefit: 2}}},
+					{UID: "T2", IDSubject: 1, MinScore: 40},
+				},
+			},
+		},
+		{name: "fail uid", group: CompetitiveGroup{UIDCampaign: "C1"}, wantErr: true},
+		{name: "fail campaign", group: CompetitiveGroup{UID: "G1"}, wantErr: true},
+		{
+			name:    "fail program uid",
+			group:   CompetitiveGroup{UID: "G1", UIDCampaign: "C1", Programs: []CompetitiveGroupProgram{{Name: "Программная инженерия"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail other group",
+			group:   CompetitiveGroup{UID: "G1", UIDCampaign: "C1", Benefits: []CompetitiveBenefit{{UID: "B1", UIDCompetitiveGroup: "G2"}}},
+			wantErr: true,
+		},
+		{
+			name: "fail other test",
+			group: CompetitiveGroup{UID: "G1", UIDCampaign: "C1", EntranceTests: []EntranceTest{
+				{UID: "T1", Benefits: []EntranceTestBenefit{{UID: "TB1", UIDEntranceTest: "T2"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "fail duplicated",
+			group:   CompetitiveGroup{UID: "G1", UIDCampaign: "C1", EntranceTests: []EntranceTest{{UID: "T1"}, {UID: "T1"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail min score",
+			group:   CompetitiveGroup{UID: "G1", UIDCampaign: "C1", EntranceTests: []EntranceTest{{UID: "T1", MinScore: 101}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.group.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+//planSummary Receive the action, datatype and UID of every record of the plan.
+func planSummary(t *testing.T, plan Plan) []string {
+	var res []string
+	for _, step := range plan {
+		pkg := struct {
+			Records []struct {
+				UID  string `xml:"UID"`
+				GUID string `xml:"GUID"`
+			} `xml:",any"`
+		}{}
+		if err := xml.Unmarshal(step.Data, &pkg); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range pkg.Records {
+			res = append(res, fmt.Sprintf("%s %s %s", step.Action, step.Datatype, r.UID+r.GUID))
+		}
+	}
+	return res
+}
+
+func TestDiffCompetitiveGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		prev    *CompetitiveGroup
+		next    *CompetitiveGroup
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ok add",
+			next: &CompetitiveGroup{
+				UID: "G1", UIDCampaign: "C1",
+				Programs: []CompetitiveGroupProgram{{UID: "P1"}},
+				Benefits: []CompetitiveBenefit{{UID: "B1", IDBenefit: 1}},
+				EntranceTests: []EntranceTest{
+					{UID: "T1", MinScore: 39, Benefits: []EntranceTestBenefit{{UID: "TB1", IDBenefit: 2}}},
+					{UID: "T2", MinScore: 40},
+				},
+			},
+			want: []string{
+				"Add competitive_groups G1",
+				"Add competitive_group_programs P1",
+				"Add competitive_benefits B1",
+				"Add entrance_tests T1",
+				"Add entrance_tests T2",
+				"Add entrance_test_benefits TB1",
+			},
+		},
+		{
+			name: "ok equal",
+			prev: &CompetitiveGroup{UID: "G1", UIDCampaign: "C1", Programs: []CompetitiveGroupProgram{{UID: "P1"}}},
+			next: &CompetitiveGroup{UID: "G1", UIDCampaign: "C1", Programs: []CompetitiveGroupProgram{{UID: "P1"}}},
+		},
+		{
+			name: "ok edit group",
+			prev: &CompetitiveGroup{UID: "G1", UIDCampaign: "C1", AdmissionNumber: 25, Programs: []CompetitiveGroupProgram{{UID: "P1"}}},
+			next: &CompetitiveGroup{UID: "G1", UIDCampaign: "C1", AdmissionNumber: 30, Programs: []CompetitiveGroupProgram{{UID: "P1"}}},
+			want: []string{"Edit competitive_groups G1"},
+		},
+		{
+			name: "ok records",
+			prev: &CompetitiveGroup{
+				UID: "G1", UIDCampaign: "C1",
+				Programs: []CompetitiveGroupProgram{{UID: "P1"}},
+				EntranceTests: []EntranceTest{
+					{UID: "T1", MinScore: 39, Benefits: []EntranceTestBenefit{{UID: "TB1", IDBenefit: 2}}},
+					{UID: "T2", MinScore: 40},
+				},
+			},
+			next: &CompetitiveGroup{
+				UID: "G1", UIDCampaign: "C1",
+				EntranceTests: []EntranceTest{
+					{UID: "T1", MinScore: 39},
+					{UID: "T2", MinScore: 45, Benefits: []EntranceTestBenefit{{UID: "TB2", IDBenefit: 2}}},
+				},
+			},
+			want: []string{
+				"Remove entrance_test_benefits TB1",
+				"Remove competitive_group_programs P1",
+				"Edit entrance_tests T2",
+				"Add entrance_test_benefits TB2",
+			},
+		},
+		{
+			name: "ok remove",
+			prev: &CompetitiveGroup{
+				UID: "G1", UIDCampaign: "C1",
+				Benefits: []CompetitiveBenefit{{UID: "B1", IDBenefit: 1}},
+				EntranceTests: []EntranceTest{
+					{UID: "T1", Benefits: []EntranceTestBenefit{{UID: "TB1", IDBenefit: 2}}},
+					{UID: "T2"},
+				},
+			},
+			want: []string{
+				"Remove entrance_test_benefits TB1",
+				"Remove entrance_tests T1",
+				"Remove entrance_tests T2",
+				"Remove competitive_benefits B1",
+				"Remove competitive_groups G1",
+			},
+		},
+		{
+			name: "ok replace",
+			prev: &CompetitiveGroup{UID: "G1", UIDCampaign: "C1", Programs: []CompetitiveGroupProgram{{UID: "P1"}}},
+			next: &CompetitiveGroup{UID: "G2", UIDCampaign: "C1", Programs: []CompetitiveGroupProgram{{UID: "P2"}}},
+			want: []string{
+				"Remove competitive_group_programs P1",
+				"Remove competitive_groups G1",
+				"Add competitive_groups G2",
+				"Add competitive_group_programs P2",
+			},
+		},
+		{
+			name:    "fail invalid",
+			next:    &CompetitiveGroup{UID: "G1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := DiffCompetitiveGroups(tt.prev, tt.next)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffCompetitiveGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := planSummary(t, plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffCompetitiveGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompetitiveGroup_Diff_send(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+
+	for _, record := range []struct {
+		datatype message.Datatype
+		data     string
+	}{
+		{datatype: message.DatatypeSubdivisionOrg, data: "<PackageData><SubdivisionOrg><UID>S1</UID></SubdivisionOrg></PackageData>"},
+		{datatype: message.DatatypeCampaign, data: "<PackageData><Campaign><UID>C1</UID></Campaign></PackageData>"},
+	} {
+		if _, err := ts.Enqueue(message.ActionAdd, record.datatype, []byte(record.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prev := &CompetitiveGroup{
+		UID: "G1", UIDCampaign: "C1", UIDSubdivisionOrg: "S1", Name: "Информатика, бюджет",
+		IDEducationLevel: 2, IDEducationSource: 1, IDEducationForm: 1, AdmissionNumber: 25,
+		Programs: []CompetitiveGroupProgram{{UID: "P1", Name: "Программная инженерия"}},
+		Benefits: []CompetitiveBenefit{{UID: "B1", IDBenefit: 1, IDOlympicDiplomaType: 1}},
+		EntranceTests: []EntranceTest{
+			{UID: "T1", IDEntranceTestType: 1, IDSubject: 2, MinScore: 39, Priority: 1,
+				Benefits: []EntranceTestBenefit{{UID: "TB1", IDBenefit: 2}}},
+			{UID: "T2", IDEntranceTestType: 1, IDSubject: 1, MinScore: 40, Priority: 2},
+		},
+	}
+	next := &CompetitiveGroup{
+		UID: "G1", UIDCampaign: "C1", UIDSubdivisionOrg: "S1", Name: "Информатика, бюджет",
+		IDEducationLevel: 2, IDEducationSource: 1, IDEducationForm: 1, AdmissionNumber: 25,
+		Programs: []CompetitiveGroupProgram{{UID: "P1", Name: "Программная инженерия"}, {UID: "P2", Name: "Информатика"}},
+		Benefits: []CompetitiveBenefit{{UID: "B1", IDBenefit: 1, IDOlympicDiplomaType: 1}},
+		EntranceTests: []EntranceTest{
+			{UID: "T2", IDEntranceTestType: 1, IDSubject: 1, MinScore: 40, Priority: 2},
+		},
+	}
+
+	var plan Plan
+	for _, diff := range []struct{ prev, next *CompetitiveGroup }{{next: prev}, {prev: prev, next: next}, {prev: next}} {
+		steps, err := DiffCompetitiveGroups(diff.prev, diff.next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plan = append(plan, steps...)
+	}
+	for _, msg := range plan.Messages(ts.Crypto) {
+		if _, err := ts.Client.Send(ctx, msg).Data(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, msg := range ts.Queue() {
+		res := test_server_epgu.Result{}
+		if err := xml.Unmarshal(msg.Response, &res); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Result {
+			t.Errorf("%s %s: %v", msg.Action, msg.Datatype, res.Errors)
+		}
+	}
+	for _, datatype := range CompetitiveGroupDatatypes {
+		if keys := ts.Entities(datatype); len(keys) != 0 {
+			t.Errorf("Entities(%s) = %v, want empty", datatype, keys)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//ErrUnsupported The datatype has no record struct.
+var ErrUnsupported = errors.New("model: unsupported datatype")
+
+//Records Records of the datatype received by message.ActionGet, only the field of the datatype is filled.
+//The records are the same structs used by Add and Edit, the nested records of the aggregates are not filled.
+//The datatype of BenefitDocuments is set from Datatype.
+type Records struct {
+	Datatype message.Datatype
+
+	SubdivisionOrgs          []SubdivisionOrg
+	Campaigns                []Campaign
+	AdmissionVolumes         []AdmissionVolume
+	DistributedVolumes       []DistributedAdmissionVolume
+	CompetitiveGroups        []CompetitiveGroup
+	CompetitiveGroupPrograms []CompetitiveGroupProgram
+	CompetitiveBenefits      []CompetitiveBenefit
+	EntranceTests            []EntranceTest
+	EntranceTestBenefits     []EntranceTestBenefit
+	Entrants                 []Entrant
+	Identifications          []Identification
+	Educations               []Education
+	EgeResults               []EgeResult
+	BenefitDocuments         []BenefitDocument
+	Compositions             []Composition
+	Applications             []Application
+	ApplicationStatuses      []ApplicationStatusChange
+	OrderAdmissions          []OrderAdmission
+	EntranceTestResults      []EntranceTestResult
+	EntranceTestsAgreed      []EntranceTestAgreed
+	PhotoFiles               []PhotoFile
+	Achievements             []Achievement
+	AppAchievements          []AppAchievement
+	RatingPlaces             []RatingPlace
+	RatingLists              []RatingList
+}
+
+//recordsFields Field of Records with the records of the datatype.
+var recordsFields = map[message.Datatype]string{
+	message.DatatypeSubdivisionOrg:             "SubdivisionOrgs",
+	message.DatatypeCampaign:                   "Campaigns",
+	message.DatatypeAdmissionVolume:            "AdmissionVolumes",
+	message.DatatypeDistributedAdmissionVolume: "DistributedVolumes",
+	message.DatatypeCompetitiveGroups:          "CompetitiveGroups",
+	message.DatatypeCompetitiveGroupPrograms:   "CompetitiveGroupPrograms",
+	message.DatatypeCompetitiveBenefits:        "CompetitiveBenefits",
+	message.DatatypeEntranceTests:              "EntranceTests",
+	message.DatatypeEntranceTestBenefits:       "EntranceTestBenefits",
+	message.DatatypeEntrants:                   "Entrants",
+	message.DatatypeIdentification:             "Identifications",
+	message.DatatypeEducations:                 "Educations",
+	message.DatatypeEge:                        "EgeResults",
+	message.DatatypeComposition:                "Compositions",
+	message.DatatypeApplications:               "Applications",
+	message.DatatypeEditApplicationStatus:      "ApplicationStatuses",
+	message.DatatypeOrderAdmission:             "OrderAdmissions",
+	message.DatatypeEntranceTestResult:         "EntranceTestResults",
+	message.DatatypeEntranceTestAgreed:         "EntranceTestsAgreed",
+	message.DatatypeEntrantPhotoFiles:          "PhotoFiles",
+	message.DatatypeAchievements:               "Achievements",
+	message.DatatypeAppAchievements:            "AppAchievements",
+
+	message.DatatypeApplicationsRating:                   "RatingPlaces",
+	message.DatatypeCompetitiveGroupsApplicationsRating:  "RatingLists",
+	message.DatatypeCompletitiveGroupsApplicationsRating: "RatingLists",
+}
+
+func init() {
+	for _, datatype := range BenefitDatatypes {
+		recordsFields[datatype] = "BenefitDocuments"
+	}
+}
+
+//IsSupported Checking the datatype that it has the record struct.
+func IsSupported(datatype message.Datatype) bool {
+	_, ok := recordsFields[datatype]
+	return ok
+}
+
+//field Receive the slice of the records of the datatype.
+func (r *Records) field() (reflect.Value, error) {
+	name, ok := recordsFields[r.Datatype]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: %q", ErrUnsupported, r.Datatype)
+	}
+	return reflect.ValueOf(r).Elem().FieldByName(name), nil
+}
+
+//DecodeRecords Read the records of the datatype from the PackageData.
+func DecodeRecords(datatype message.Datatype, data []byte) (*Records, error) {
+	r := &Records{Datatype: datatype}
+	slice, err := r.field()
+	if err != nil {
+		return nil, err
+	}
+	if err = decodePackageData(data, slice.Addr().Interface()); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, datatype)
+	}
+	for i := range r.BenefitDocuments {
+		r.BenefitDocuments[i].Datatype = datatype
+	}
+	return r, nil
+}
+
+//Records Receive the records of the datatype of the message from the result of message.ActionGet.
+func (i *Info) Records() (*Records, error) {
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	data, err := i.PackageData()
+	if err != nil {
+		return nil, err
+	}
+	return DecodeRecords(i.Datatype, data)
+}
+
+//Len Receive the number of the records.
+func (r *Records) Len() int {
+	slice, err := r.field()
+	if err != nil {
+		return 0
+	}
+	return slice.Len()
+}
+
+//Step Receive the step of the action with the records, for example message.ActionEdit after the records are modified.
+func (r *Records) Step(action message.Action) (Step, error) {
+	slice, err := r.field()
+	if err != nil {
+		return Step{}, err
+	}
+	if slice.Len() == 0 {
+		return Step{}, invalidf("%s: records are empty", r.Datatype)
+	}
+	records := make([]interface{}, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		records = append(records, slice.Index(i).Interface())
+	}
+	data, err := packageData(records...)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Action: action, Datatype: r.Datatype, Data: data}, nil
+}
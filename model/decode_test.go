@@ -0,0 +1,189 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestDecodeRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		datatype message.Datatype
+		data     string
+		wantLen  int
+		wantErr  bool
+	}{
+		{
+			name:     "ok campaign",
+			datatype: message.DatatypeCampaign,
+			data: "<PackageData><Campaign><UID>C1</UID><Name>Бакалавриат</Name><YearStart>2020</YearStart><YearEnd>2020</YearEnd>" +
+				"<IdCampaignType>1</IdCampaignType><IdCampaignStatus>1</IdCampaignStatus><EndDate>2020-08-31</EndDate></Campaign></PackageData>",
+			wantLen: 1,
+		},
+		{
+			name:     "ok entrance tests",
+			datatype: message.DatatypeEntranceTests,
+			data: "<PackageData><EntranceTest><UID>T1</UID><UIDCompetitiveGroup>G1</UIDCompetitiveGroup><IdEntranceTestType>1</IdEntranceTestType>" +
+				"<MinScore>39</MinScore><Priority>1</Priority></EntranceTest><EntranceTest><UID>T2</UID><UIDCompetitiveGroup>G1</UIDCompetitiveGroup>" +
+				"<IdEntranceTestType>1</IdEntranceTestType><MinScore>40</MinScore><Priority>2</Priority></EntranceTest></PackageData>",
+			wantLen: 2,
+		},
+		{
+			name:     "ok orphans",
+			datatype: message.DatatypeOrphans,
+			data: "<PackageData><Orphan><UID>O1</UID><GUIDEntrant>E1</GUIDEntrant><DocNumber>1</DocNumber>" +
+				"<IssueDate>2019-01-10</IssueDate><IdCategory>2</IdCategory></Orphan></PackageData>",
+			wantLen: 1,
+		},
+		{
+			name:     "ok application",
+			datatype: message.DatatypeApplications,
+			data: "<PackageData><Application><UID>A1</UID><GUIDEntrant>E1</GUIDEntrant><UIDCompetitiveGroup>G1</UIDCompetitiveGroup>" +
+				"<RegistrationDate>2020-07-01</RegistrationDate><IdStatus>2</IdStatus><NeedHostel>true</NeedHostel></Application></PackageData>",
+			wantLen: 1,
+		},
+		{
+			name:     "ok distributed admission volume",
+			datatype: message.DatatypeDistributedAdmissionVolume,
+			data: "<PackageData><DistributedAdmissionVolume><UID>D1</UID><UIDAdmissionVolume>V1</UIDAdmissionVolume><IdLevelBudget>1</IdLevelBudget>" +
+				"<BudgetO>20</BudgetO><BudgetOZ>0</BudgetOZ><BudgetZ>0</BudgetZ><QuotaO>2</QuotaO><QuotaOZ>0</QuotaOZ><QuotaZ>0</QuotaZ>" +
+				"</DistributedAdmissionVolume></PackageData>",
+			wantLen: 1,
+		},
+		{
+			name:     "ok composition",
+			datatype: message.DatatypeComposition,
+			data: "<PackageData><Composition><UID>K1</UID><GUIDEntrant>E1</GUIDEntrant><IdCompositionTheme>3</IdCompositionTheme>" +
+				"<Year>2020</Year><Result>true</Result><HasAppeal>false</HasAppeal></Composition></PackageData>",
+			wantLen: 1,
+		},
+		{
+			name:     "ok entrance test agreed",
+			datatype: message.DatatypeEntranceTestAgreed,
+			data: "<PackageData><EntranceTestAgreed><UID>TA1</UID><UIDApplication>A1</UIDApplication><UIDEntranceTest>T1</UIDEntranceTest>" +
+				"<IsAgreed>true</IsAgreed><AgreedDate>2020-07-10</AgreedDate></EntranceTestAgreed></PackageData>",
+			wantLen: 1,
+		},
+		{name: "ok empty", datatype: message.DatatypeEntrants, data: "<PackageData></PackageData>"},
+		{name: "fail element", datatype: message.DatatypeCampaign, data: "<PackageData><Entrant><GUID>E1</GUID></Entrant></PackageData>", wantErr: true},
+		{name: "fail unsupported", datatype: message.Datatype("unknown"), data: "<PackageData></PackageData>", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeRecords(tt.datatype, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeRecords() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Len() != tt.wantLen {
+				t.Fatalf("Len() = %d, want %d", got.Len(), tt.wantLen)
+			}
+			for _, doc := range got.BenefitDocuments {
+				if doc.Datatype != tt.datatype {
+					t.Errorf("BenefitDocuments Datatype = %q, want %q", doc.Datatype, tt.datatype)
+				}
+			}
+			if tt.wantLen == 0 {
+				if _, err = got.Step(message.ActionEdit); !errors.Is(err, ErrInvalid) {
+					t.Errorf("Step() of the empty records error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			step, err := got.Step(message.ActionEdit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(step.Data) != tt.data || step.Datatype != tt.datatype {
+				t.Errorf("Step() = %s %s, want %s", step.Datatype, step.Data, tt.data)
+			}
+		})
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	for _, datatype := range message.AllDataType {
+		if !IsSupported(datatype) {
+			t.Errorf("IsSupported(%q) = false", datatype)
+		}
+	}
+	if IsSupported(message.Datatype("unknown")) {
+		t.Errorf("IsSupported(unknown) = true")
+	}
+}
+
+func TestInfo_Records(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+	opt := SetPollInterval(time.Millisecond)
+
+	entrant := &Entrant{
+		GUID:     "E1",
+		Surname:  "Иванов",
+		Name:     "Иван",
+		IDGender: 1,
+		Birthday: NewDate(2003, time.May, 1),
+		Identifications: []Identification{{
+			Document: Document{UID: "P1", IDDocumentType: 1, DocNumber: "123456", IssueDate: NewDate(2017, time.June, 1)},
+			Surname:  "Иванов",
+			Name:     "Иван",
+		}},
+	}
+	plan, err := entrant.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewReconciler(ts.Client, ts.Crypto, opt).Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+
+	get := Step{Action: message.ActionGet, Datatype: message.DatatypeIdentification,
+		Data: []byte("<PackageData><Identification><UID>P1</UID></Identification></PackageData>")}
+	info, err := Exchange(ctx, ts.Client, ts.Crypto, get, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records, err := info.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records.Identifications) != 1 || records.Identifications[0].DocNumber != "123456" ||
+		!records.Identifications[0].IssueDate.Equal(NewDate(2017, time.June, 1).Time) {
+		t.Fatalf("Records() = %+v", records.Identifications)
+	}
+
+	records.Identifications[0].DocNumber = "654321"
+	edit, err := records.Step(message.ActionEdit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Exchange(ctx, ts.Client, ts.Crypto, edit, opt); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := ts.Entity(message.DatatypeIdentification, "P1"); !strings.Contains(string(data), "<DocNumber>654321</DocNumber>") {
+		t.Errorf("Entity() = %s", data)
+	}
+
+	get.Data = []byte("<PackageData><Identification><UID>P2</UID></Identification></PackageData>")
+	info, err = Exchange(ctx, ts.Client, ts.Crypto, get, opt)
+	if !errors.Is(err, ErrRejected) {
+		t.Fatalf("Exchange() error = %v, want %v", err, ErrRejected)
+	}
+	if _, err = info.Records(); !errors.Is(err, ErrRejected) {
+		t.Errorf("Records() error = %v, want %v", err, ErrRejected)
+	}
+}
@@ -0,0 +1,443 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//Columns of the file with the results, the headers of the file are mapped to them by SetResultColumns.
+const (
+	ResultColumnUID             = "UID"
+	ResultColumnGUIDEntrant     = "GUIDEntrant"
+	ResultColumnUIDEntranceTest = "UIDEntranceTest"
+	ResultColumnSubject         = "IdSubject"
+	ResultColumnResultValue     = "ResultValue"
+	ResultColumnResultSource    = "IdEntranceTestResultSource"
+	ResultColumnResultDate      = "ResultDate"
+)
+
+//requiredResultColumns Columns that the file must have.
+var requiredResultColumns = []string{
+	ResultColumnUID,
+	ResultColumnGUIDEntrant,
+	ResultColumnSubject,
+	ResultColumnResultValue,
+}
+
+//EntranceTestResult Result of the entrance test of the entrant, datatype message.DatatypeEntranceTestResult.
+type EntranceTestResult struct {
+	XMLName                    xml.Name `xml:"EntranceTestResult"`
+	UID                        string   `xml:"UID"`
+	GUIDEntrant                string   `xml:"GUIDEntrant"`
+	UIDEntranceTest            string   `xml:"UIDEntranceTest,omitempty"`
+	IDSubject                  int      `xml:"IdSubject"`
+	ResultValue                int      `xml:"ResultValue"`
+	IDEntranceTestResultSource int      `xml:"IdEntranceTestResultSource,omitempty"`
+	ResultDate                 Date     `xml:"ResultDate"`
+}
+
+//EntranceTestAgreed Agreement of the entrant to pass the entrance test of the application, datatype message.DatatypeEntranceTestAgreed.
+type EntranceTestAgreed struct {
+	XMLName         xml.Name `xml:"EntranceTestAgreed"`
+	UID             string   `xml:"UID"`
+	UIDApplication  string   `xml:"UIDApplication"`
+	UIDEntranceTest string   `xml:"UIDEntranceTest"`
+	IsAgreed        bool     `xml:"IsAgreed"`
+	AgreedDate      Date     `xml:"AgreedDate"`
+}
+
+//RowError Error of the row of the file, Row is numbered from 1 including the header.
+type RowError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d: %s: %s", e.Row, e.Column, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+//ImportReport Results of the file and the errors of the rows skipped.
+type ImportReport struct {
+	Rows    int
+	Results []EntranceTestResult
+	Errors  []RowError
+}
+
+//Err Receive the error joining the errors of the rows, nil if all rows are imported.
+func (r *ImportReport) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		msgs = append(msgs, e.Error())
+	}
+	return invalidf("%d of %d rows: %s", len(r.Errors), r.Rows, strings.Join(msgs, "; "))
+}
+
+type importOptions struct {
+	columns        map[string]string
+	comma          rune
+	educationLevel int
+	source         int
+	batchSize      int
+}
+
+//ImportOption Option of ResultImporter.
+type ImportOption func(*importOptions)
+
+//SetResultColumns To set the mapping of the headers of the file to the ResultColumn columns,
+//the headers equal to the columns are mapped by default.
+func SetResultColumns(columns map[string]string) ImportOption {
+	return func(o *importOptions) {
+		for header, column := range columns {
+			o.columns[normalizeHeader(header)] = column
+		}
+	}
+}
+
+//SetComma To set the separator of the CSV, by default ';'.
+func SetComma(comma rune) ImportOption {
+	return func(o *importOptions) {
+		o.comma = comma
+	}
+}
+
+//SetEducationLevel To set the education level of CLSMinScoreSubjects checked, by default the minimal score of any level.
+func SetEducationLevel(id int) ImportOption {
+	return func(o *importOptions) {
+		o.educationLevel = id
+	}
+}
+
+//SetResultSource To set CLSEntranceTestResultSources of the rows without the column ResultColumnResultSource.
+func SetResultSource(id int) ImportOption {
+	return func(o *importOptions) {
+		o.source = id
+	}
+}
+
+//SetBatchSize To set the maximum number of the results in the message, by default 100.
+func SetBatchSize(size int) ImportOption {
+	return func(o *importOptions) {
+		o.batchSize = size
+	}
+}
+
+//ResultImporter Importer of the results of the entrance tests from the CSV and XLSX files.
+type ResultImporter struct {
+	subjects  *Classifier
+	minScores *Classifier
+	options   importOptions
+}
+
+//NewResultImporter Creating a new ResultImporter for the classifiers CLSSubject and CLSMinScoreSubjects,
+//supports the following options: SetResultColumns, SetComma, SetEducationLevel, SetResultSource, SetBatchSize.
+func NewResultImporter(subjects, minScores *Classifier, opts ...ImportOption) *ResultImporter {
+	options := importOptions{columns: map[string]string{}, comma: ';', batchSize: 100}
+	for _, column := range []string{ResultColumnUID, ResultColumnGUIDEntrant, ResultColumnUIDEntranceTest, ResultColumnSubject,
+		ResultColumnResultValue, ResultColumnResultSource, ResultColumnResultDate} {
+		options.columns[normalizeHeader(column)] = column
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &ResultImporter{subjects: subjects, minScores: minScores, options: options}
+}
+
+//LoadResultImporter Creating a new ResultImporter for the classifiers received from the service.
+func LoadResultImporter(ctx context.Context, client sspvo.Client, opts ...ImportOption) (*ResultImporter, error) {
+	subjects, err := LoadClassifier(ctx, client, message.CLSSubject)
+	if err != nil {
+		return nil, err
+	}
+	minScores, err := LoadClassifier(ctx, client, message.CLSMinScoreSubjects)
+	if err != nil {
+		return nil, err
+	}
+	return NewResultImporter(subjects, minScores, opts...), nil
+}
+
+//ImportCSV Read the results from the CSV with the header.
+func (i *ResultImporter) ImportCSV(r io.Reader) (*ImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = i.options.comma
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("model: csv: %w", err)
+	}
+	return i.Import(rows)
+}
+
+//ImportXLSX Read the results from the first sheet of the XLSX with the header.
+func (i *ResultImporter) ImportXLSX(r io.ReaderAt, size int64) (*ImportReport, error) {
+	rows, nums, err := readXLSX(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("model: %w", err)
+	}
+	return i.importRows(rows, nums)
+}
+
+//ImportFile Read the results from the file, the format is chosen by the extension .csv or .xlsx.
+func (i *ResultImporter) ImportFile(name string) (*ImportReport, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("model: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return i.ImportCSV(bytes.NewReader(data))
+	case ".xlsx":
+		return i.ImportXLSX(bytes.NewReader(data), int64(len(data)))
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupported, name)
+}
+
+//Import Read the results from the rows, the first row is the header.
+//The error is returned only if the header is bad, the bad rows are skipped and reported in ImportReport.Errors.
+func (i *ResultImporter) Import(rows [][]string) (*ImportReport, error) {
+	return i.importRows(rows, nil)
+}
+
+//importRows Read the results from the rows with the numbers of the rows in the file, nums nil numbers them from 1.
+func (i *ResultImporter) importRows(rows [][]string, nums []int) (*ImportReport, error) {
+	if len(rows) == 0 {
+		return nil, invalidf("header not found")
+	}
+	index := map[string]int{}
+	for n, header := range rows[0] {
+		column, ok := i.options.columns[normalizeHeader(header)]
+		if !ok {
+			continue
+		}
+		if _, ok = index[column]; ok {
+			return nil, invalidf("column %s is duplicated", column)
+		}
+		index[column] = n
+	}
+	for _, column := range requiredResultColumns {
+		if _, ok := index[column]; !ok {
+			return nil, invalidf("column %s not found", column)
+		}
+	}
+
+	report := &ImportReport{}
+	uids := map[string]int{}
+	for n, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+		report.Rows++
+		rowNum := n + 2
+		if nums != nil {
+			rowNum = nums[n+1]
+		}
+		value := func(column string) string {
+			idx, ok := index[column]
+			if !ok || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+		result, rowErr := i.parseRow(value)
+		if rowErr == nil {
+			if prev, ok := uids[result.UID]; ok {
+				rowErr = &RowError{Column: ResultColumnUID, Err: invalidf("duplicated of row %d", prev)}
+			}
+		}
+		if rowErr != nil {
+			rowErr.Row = rowNum
+			report.Errors = append(report.Errors, *rowErr)
+			continue
+		}
+		uids[result.UID] = rowNum
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+//parseRow Read and check the result of the row.
+func (i *ResultImporter) parseRow(value func(column string) string) (EntranceTestResult, *RowError) {
+	fail := func(column string, err error) (EntranceTestResult, *RowError) {
+		return EntranceTestResult{}, &RowError{Column: column, Err: err}
+	}
+	result := EntranceTestResult{
+		UID:             value(ResultColumnUID),
+		GUIDEntrant:     value(ResultColumnGUIDEntrant),
+		UIDEntranceTest: value(ResultColumnUIDEntranceTest),
+	}
+	if result.UID == "" {
+		return fail(ResultColumnUID, invalidf("empty"))
+	}
+	if result.GUIDEntrant == "" {
+		return fail(ResultColumnGUIDEntrant, invalidf("empty"))
+	}
+
+	subject, ok := i.subject(value(ResultColumnSubject))
+	if !ok {
+		return fail(ResultColumnSubject, invalidf("subject %q not found", value(ResultColumnSubject)))
+	}
+	result.IDSubject = subject.ID
+
+	score, err := parseCellInt(value(ResultColumnResultValue))
+	if err != nil {
+		return fail(ResultColumnResultValue, err)
+	}
+	if score < 0 || score > 100 {
+		return fail(ResultColumnResultValue, invalidf("%d out of range 0-100", score))
+	}
+	if min, ok := i.minScore(subject.ID); ok && score < min {
+		return fail(ResultColumnResultValue, invalidf("%d less than the minimal score %d of %s", score, min, subject.Name))
+	}
+	result.ResultValue = score
+
+	result.IDEntranceTestResultSource = i.options.source
+	if s := value(ResultColumnResultSource); s != "" {
+		if result.IDEntranceTestResultSource, err = parseCellInt(s); err != nil {
+			return fail(ResultColumnResultSource, err)
+		}
+	}
+	if s := value(ResultColumnResultDate); s != "" {
+		if result.ResultDate, err = parseCellDate(s); err != nil {
+			return fail(ResultColumnResultDate, err)
+		}
+	}
+	return result, nil
+}
+
+//subject Receive the actual subject by ID or by name.
+func (i *ResultImporter) subject(s string) (ClassifierItem, bool) {
+	if s == "" {
+		return ClassifierItem{}, false
+	}
+	if id, err := parseCellInt(s); err == nil {
+		item, ok := i.subjects.Item(id)
+		return item, ok && item.Actual
+	}
+	for _, item := range i.subjects.Items {
+		if item.Actual && strings.EqualFold(item.Name, s) {
+			return item, true
+		}
+	}
+	return ClassifierItem{}, false
+}
+
+//minScore Receive the minimal score of the subject for the education level, the lowest one if the level is not set.
+func (i *ResultImporter) minScore(subject int) (int, bool) {
+	min, found := 0, false
+	for _, item := range i.minScores.Items {
+		if !item.Actual || item.Int("IDSubject") != subject {
+			continue
+		}
+		if i.options.educationLevel != 0 && item.Int("IDEducationLevel") != i.options.educationLevel {
+			continue
+		}
+		if score := item.Int("MinScore"); !found || score < min {
+			min, found = score, true
+		}
+	}
+	return min, found
+}
+
+//Plan Receive the plan adding the results by the batches of SetBatchSize.
+func (i *ResultImporter) Plan(results []EntranceTestResult) (Plan, error) {
+	size := i.options.batchSize
+	if size <= 0 {
+		size = len(results)
+	}
+	var plan Plan
+	for start := 0; start < len(results); start += size {
+		end := start + size
+		if end > len(results) {
+			end = len(results)
+		}
+		records := make([]interface{}, 0, end-start)
+		for _, result := range results[start:end] {
+			records = append(records, result)
+		}
+		data, err := packageData(records...)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, Step{Action: message.ActionAdd, Datatype: message.DatatypeEntranceTestResult, Data: data})
+	}
+	return plan, nil
+}
+
+//Messages Receive the messages adding the results of the report.
+func (i *ResultImporter) Messages(crypto sspvo.Crypto, report *ImportReport) ([]*message.ActionMessage, error) {
+	plan, err := i.Plan(report.Results)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Messages(crypto), nil
+}
+
+func normalizeHeader(header string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+}
+
+func isEmptyRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+//parseCellInt Read the integer, the numbers of XLSX like 75.0 are accepted.
+func parseCellInt(s string) (int, error) {
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, invalidf("bad number %q", s)
+	}
+	return int(f), nil
+}
+
+//parseCellDate Read the date as DateLayout, DD.MM.YYYY or the serial number of the XLSX date.
+func parseCellDate(s string) (Date, error) {
+	for _, layout := range []string{DateLayout, "02.01.2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Date{Time: t}, nil
+		}
+	}
+	if days, err := strconv.ParseFloat(s, 64); err == nil && days > 0 {
+		t := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(days))
+		return Date{Time: t}, nil
+	}
+	return Date{}, invalidf("bad date %q", s)
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func TestResultImporter_ImportCSV(t *testing.T) {
+	subjects := testClassifier(t, message.CLSSubject)
+	minScores := testClassifier(t, message.CLSMinScoreSubjects)
+	i := NewResultImporter(subjects, minScores, SetResultSource(1),
+		SetResultColumns(map[string]string{"Абитуриент": ResultColumnGUIDEntrant, "Предмет": ResultColumnSubject, "Балл": ResultColumnResultValue}))
+	csvData := "UID;Абитуриент;Предмет;Балл;ResultDate\n" +
+		"R1;E1;1;70;20.07.2020\n" +
+		"R2;E1;Математика;27;\n" +
+		";;;;\n" +
+		"R3;E2;2;26;\n" +
+		"R4;E2;100;80;\n" +
+		"R1;E3;1;90;\n" +
+		"R5;;1;90;\n" +
+		"R6;E3;1;101;\n" +
+		"R7;E3;1;abc;\n" +
+		"R8;E3;1;50;2020-13-01\n"
+
+	report, err := i.ImportCSV(strings.NewReader(csvData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []EntranceTestResult{
+		{UID: "R1", GUIDEntrant: "E1", IDSubject: 1, ResultValue: 70, IDEntranceTestResultSource: 1, ResultDate: NewDate(2020, time.July, 20)},
+		{UID: "R2", GUIDEntrant: "E1", IDSubject: 2, ResultValue: 27, IDEntranceTestResultSource: 1},
+	}
+	if len(report.Results) != len(want) {
+		t.Fatalf("Results = %+v, want %+v", report.Results, want)
+	}
+	for n := range want {
+		got := report.Results[n]
+		if got.UID != want[n].UID || got.GUIDEntrant != want[n].GUIDEntrant || got.IDSubject != want[n].IDSubject ||
+			got.ResultValue != want[n].ResultValue || got.IDEntranceTestResultSource != want[n].IDEntranceTestResultSource ||
+			!got.ResultDate.Equal(want[n].ResultDate.Time) {
+			t.Errorf("Results[%d] = %+v, want %+v", n, got, want[n])
+		}
+	}
+
+	wantErrors := []RowError{
+		{Row: 5, Column: ResultColumnResultValue},
+		{Row: 6, Column: ResultColumnSubject},
+		{Row: 7, Column: ResultColumnUID},
+		{Row: 8, Column: ResultColumnGUIDEntrant},
+		{Row: 9, Column: ResultColumnResultValue},
+		{Row: 10, Column: ResultColumnResultValue},
+		{Row: 11, Column: ResultColumnResultDate},
+	}
+	if report.Rows != 9 || len(report.Errors) != len(wantErrors) {
+		t.Fatalf("Rows = %d, Errors = %v", report.Rows, report.Errors)
+	}
+	for n, e := range wantErrors {
+		if report.Errors[n].Row != e.Row || report.Errors[n].Column != e.Column || !errors.Is(report.Errors[n], ErrInvalid) {
+			t.Errorf("Errors[%d] = %v, want row %d column %s", n, report.Errors[n], e.Row, e.Column)
+		}
+	}
+	if err = report.Err(); !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), "7 of 9 rows") {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestResultImporter_Import_header(t *testing.T) {
+	subjects := testClassifier(t, message.CLSSubject)
+	minScores := testClassifier(t, message.CLSMinScoreSubjects)
+	i := NewResultImporter(subjects, minScores)
+	tests := []struct {
+		name    string
+		rows    [][]string
+		wantErr bool
+	}{
+		{name: "ok", rows: [][]string{{" uid ", "GUIDEntrant", "IdSubject", "ResultValue"}}},
+		{name: "fail empty", rows: nil, wantErr: true},
+		{name: "fail missing column", rows: [][]string{{"UID", "GUIDEntrant", "IdSubject"}}, wantErr: true},
+		{name: "fail duplicated column", rows: [][]string{{"UID", "UID", "GUIDEntrant", "IdSubject", "ResultValue"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := i.Import(tt.rows)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResultImporter_minScore(t *testing.T) {
+	subjects := &Classifier{CLS: message.CLSSubject, Items: []ClassifierItem{{ID: 1, Name: "Русский язык", Actual: true}}}
+	minScores := &Classifier{CLS: message.CLSMinScoreSubjects, Items: []ClassifierItem{
+		{ID: 1, Actual: true, Fields: map[string]string{"IDSubject": "1", "IDEducationLevel": "2", "MinScore": "36"}},
+		{ID: 2, Actual: true, Fields: map[string]string{"IDSubject": "1", "IDEducationLevel": "5", "MinScore": "40"}},
+	}}
+	rows := [][]string{{"UID", "GUIDEntrant", "IdSubject", "ResultValue"}, {"R1", "E1", "1", "38"}}
+	tests := []struct {
+		name  string
+		level int
+		want  int
+	}{
+		{name: "any level", want: 1},
+		{name: "level 2", level: 2, want: 1},
+		{name: "level 5", level: 5, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewResultImporter(subjects, minScores, SetEducationLevel(tt.level)).Import(rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(report.Results) != tt.want {
+				t.Errorf("Results = %v, Errors = %v, want %d", report.Results, report.Errors, tt.want)
+			}
+		})
+	}
+}
+
+//testXLSX Receive the XLSX file with the rows of the first sheet, the nil rows are absent in the file as the empty rows.
+func testXLSX(t *testing.T, rows [][]string) []byte {
+	shared := &strings.Builder{}
+	sheet := &strings.Builder{}
+	n := 0
+	for r, row := range rows {
+		if row == nil {
+			continue
+		}
+		sheet.WriteString(`<row r="` + strconv.Itoa(r+1) + `">`)
+		for c, cell := range row {
+			ref := string(rune('A'+c)) + strconv.Itoa(r+1)
+			if _, err := parseCellInt(cell); err == nil {
+				sheet.WriteString(`<c r="` + ref + `"><v>` + cell + `</v></c>`)
+				continue
+			}
+			shared.WriteString("<si><t>" + cell + "</t></si>")
+			sheet.WriteString(`<c r="` + ref + `" t="s"><v>` + strconv.Itoa(n) + `</v></c>`)
+			n++
+		}
+		sheet.WriteString("</row>")
+	}
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Results" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       "<sst>" + shared.String() + "</sst>",
+		"xl/worksheets/sheet1.xml":   "<worksheet><sheetData>" + sheet.String() + "</sheetData></worksheet>",
+	}
+	return testZip(t, files)
+}
+
+//testZip Receive the ZIP archive with the files.
+func testZip(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, data := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestResultImporter_ImportXLSX(t *testing.T) {
+	subjects := testClassifier(t, message.CLSSubject)
+	minScores := testClassifier(t, message.CLSMinScoreSubjects)
+	i := NewResultImporter(subjects, minScores, SetBatchSize(1))
+	data := testXLSX(t, [][]string{
+		{"UID", "GUIDEntrant", "IdSubject", "ResultValue", "ResultDate"},
+		{"R1", "E1", "1", "70", "44032"},
+		nil,
+		nil,
+		{"R2", "E2", "Физика", "20", ""},
+		{"R3", "E2", "Физика", "40", ""},
+	})
+	report, err := i.ImportXLSX(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 2 || len(report.Errors) != 1 || report.Errors[0].Row != 5 {
+		t.Fatalf("Results = %v, Errors = %v", report.Results, report.Errors)
+	}
+	if !report.Results[0].ResultDate.Equal(NewDate(2020, time.July, 20).Time) || report.Results[1].IDSubject != 3 {
+		t.Errorf("Results = %+v", report.Results)
+	}
+
+	plan, err := i.Plan(report.Results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("Plan() = %v, want 2 steps", plan)
+	}
+	records, err := DecodeRecords(plan[1].Datatype, plan[1].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan[1].Action != message.ActionAdd || len(records.EntranceTestResults) != 1 || records.EntranceTestResults[0].UID != "R3" {
+		t.Errorf("Plan()[1] = %s %s", plan[1].Action, plan[1].Data)
+	}
+
+	if _, err = i.ImportXLSX(bytes.NewReader([]byte("no zip")), 6); err == nil {
+		t.Error("ImportXLSX() of bad file must fail")
+	}
+}
+
+func TestReadXLSX(t *testing.T) {
+	workbook := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Results" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="/xl/worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       "<sst><si><t>UID</t></si><si><r><t>R</t></r><r><t>1</t></r></si></sst>",
+	}
+	tests := []struct {
+		name     string
+		sheet    string
+		want     [][]string
+		wantNums []int
+		wantErr  bool
+	}{
+		{
+			name: "ok numbers",
+			sheet: `<row r="2"><c r="B2" t="s"><v>0</v></c></row>` +
+				`<row r="7"><c r="A7" t="s"><v>1</v></c><c r="C7" t="inlineStr"><is><t>E1</t></is></c></row>`,
+			want:     [][]string{{"", "UID"}, {"R1", "", "E1"}},
+			wantNums: []int{2, 7},
+		},
+		{
+			name:     "ok without references",
+			sheet:    `<row><c><v>1</v></c><c><v>2</v></c></row><row><c><v>3</v></c></row>`,
+			want:     [][]string{{"1", "2"}, {"3"}},
+			wantNums: []int{1, 2},
+		},
+		{name: "fail reference without column", sheet: `<row r="1"><c r="1"><v>1</v></c></row>`, wantErr: true},
+		{name: "fail lowercase reference", sheet: `<row r="1"><c r="a1"><v>1</v></c></row>`, wantErr: true},
+		{name: "fail column past XFD", sheet: `<row r="1"><c r="XFE1"><v>1</v></c></row>`, wantErr: true},
+		{name: "fail long column", sheet: `<row r="1"><c r="ZZZZZZZZ1"><v>1</v></c></row>`, wantErr: true},
+		{name: "fail row order", sheet: `<row r="3"><c><v>1</v></c></row><row r="2"><c><v>2</v></c></row>`, wantErr: true},
+		{name: "fail shared string", sheet: `<row r="1"><c r="A1" t="s"><v>5</v></c></row>`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{"xl/worksheets/sheet1.xml": "<worksheet><sheetData>" + tt.sheet + "</sheetData></worksheet>"}
+			for name, data := range workbook {
+				files[name] = data
+			}
+			data := testZip(t, files)
+			rows, nums, err := readXLSX(bytes.NewReader(data), int64(len(data)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readXLSX() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rows, tt.want) || !reflect.DeepEqual(nums, tt.wantNums) {
+				t.Errorf("readXLSX() = %q %v, want %q %v", rows, nums, tt.want, tt.wantNums)
+			}
+		})
+	}
+}
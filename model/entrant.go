@@ -0,0 +1,287 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/xml"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//Entrant Entrant with the documents sent by the separate datatypes.
+type Entrant struct {
+	XMLName    xml.Name `xml:"Entrant"`
+	GUID       string   `xml:"GUID"`
+	Surname    string   `xml:"Surname"`
+	Name       string   `xml:"Name"`
+	Patronymic string   `xml:"Patronymic,omitempty"`
+	Snils      string   `xml:"Snils,omitempty"`
+	IDGender   int      `xml:"IdGender"`
+	Birthday   Date     `xml:"Birthday"`
+	Birthplace string   `xml:"Birthplace,omitempty"`
+	Phone      string   `xml:"Phone,omitempty"`
+	Email      string   `xml:"Email,omitempty"`
+	IDOksm     int      `xml:"IdOksm,omitempty"`
+
+	Identifications []Identification  `xml:"-"`
+	Educations      []Education       `xml:"-"`
+	EgeResults      []EgeResult       `xml:"-"`
+	Benefits        []BenefitDocument `xml:"-"`
+}
+
+//Document Common fields of the documents of the entrant.
+type Document struct {
+	UID             string `xml:"UID"`
+	GUIDEntrant     string `xml:"GUIDEntrant"`
+	IDDocumentType  int    `xml:"IdDocumentType,omitempty"`
+	DocName         string `xml:"DocName,omitempty"`
+	DocSeries       string `xml:"DocSeries,omitempty"`
+	DocNumber       string `xml:"DocNumber"`
+	IssueDate       Date   `xml:"IssueDate"`
+	DocOrganization string `xml:"DocOrganization,omitempty"`
+}
+
+//Identification Identity document of the entrant, datatype message.DatatypeIdentification.
+type Identification struct {
+	XMLName xml.Name `xml:"Identification"`
+	Document
+	Surname         string `xml:"Surname"`
+	Name            string `xml:"Name"`
+	Patronymic      string `xml:"Patronymic,omitempty"`
+	SubdivisionCode string `xml:"SubdivisionCode,omitempty"`
+	IDOksm          int    `xml:"IdOksm,omitempty"`
+}
+
+//Education Education document of the entrant, datatype message.DatatypeEducations.
+type Education struct {
+	XMLName xml.Name `xml:"Education"`
+	Document
+	IDEducationLevel   int    `xml:"IdEducationLevel"`
+	RegistrationNumber string `xml:"RegistrationNumber,omitempty"`
+	IDOksm             int    `xml:"IdOksm,omitempty"`
+}
+
+//EgeResult Result of the unified state exam of the entrant, datatype message.DatatypeEge.
+type EgeResult struct {
+	XMLName     xml.Name `xml:"Ege"`
+	UID         string   `xml:"UID"`
+	GUIDEntrant string   `xml:"GUIDEntrant"`
+	IDSubject   int      `xml:"IdSubject"`
+	Mark        int      `xml:"Mark"`
+	Year        int      `xml:"Year,omitempty"`
+	IDRegion    int      `xml:"IdRegion,omitempty"`
+}
+
+//Composition Final composition of the entrant, datatype message.DatatypeComposition.
+type Composition struct {
+	XMLName            xml.Name `xml:"Composition"`
+	UID                string   `xml:"UID"`
+	GUIDEntrant        string   `xml:"GUIDEntrant"`
+	IDCompositionTheme int      `xml:"IdCompositionTheme"`
+	Year               int      `xml:"Year"`
+	Result             bool     `xml:"Result"`
+	HasAppeal          bool     `xml:"HasAppeal"`
+	IDAppealStatus     int      `xml:"IdAppealStatus,omitempty"`
+}
+
+//BenefitDocument Document confirming the benefit of the entrant, the datatype is one of BenefitDatatypes.
+type BenefitDocument struct {
+	Datatype message.Datatype `xml:"-"`
+	Document
+	IDCategory int `xml:"IdCategory,omitempty"`
+}
+
+//BenefitDatatypes Datatypes of the benefit documents in the order they are sent.
+var BenefitDatatypes = []message.Datatype{
+	message.DatatypeDisability,
+	message.DatatypeOrphans,
+	message.DatatypeVeteran,
+	message.DatatypeParentsLost,
+	message.DatatypeRadiationWork,
+	message.DatatypeMilitaries,
+	message.DatatypeCompatriot,
+	message.DatatypeOlympics,
+	message.DatatypeOther,
+}
+
+var benefitElements = map[message.Datatype]string{
+	message.DatatypeDisability:    "Disability",
+	message.DatatypeOrphans:       "Orphan",
+	message.DatatypeVeteran:       "Veteran",
+	message.DatatypeParentsLost:   "ParentsLost",
+	message.DatatypeRadiationWork: "RadiationWork",
+	message.DatatypeMilitaries:    "Military",
+	message.DatatypeCompatriot:    "Compatriot",
+	message.DatatypeOlympics:      "Olympic",
+	message.DatatypeOther:         "Other",
+}
+
+//benefitDocument BenefitDocument without the marshal methods.
+type benefitDocument BenefitDocument
+
+//MarshalXML Write the document to the element of its datatype.
+func (d BenefitDocument) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	element, ok := benefitElements[d.Datatype]
+	if !ok {
+		return invalidf("benefit document %s: unknown datatype %q", d.UID, d.Datatype)
+	}
+	start.Name = xml.Name{Local: element}
+	return e.EncodeElement(benefitDocument(d), start)
+}
+
+//UnmarshalXML Read the document, the datatype is taken from the element.
+func (d *BenefitDocument) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	doc := benefitDocument{}
+	if err := dec.DecodeElement(&doc, &start); err != nil {
+		return err
+	}
+	for datatype, element := range benefitElements {
+		if element == start.Name.Local {
+			doc.Datatype = datatype
+		}
+	}
+	*d = BenefitDocument(doc)
+	return nil
+}
+
+//Validate Check the entrant and its documents before sending.
+func (e *Entrant) Validate() error {
+	if e.GUID == "" {
+		return invalidf("entrant: GUID is empty")
+	}
+	if e.Surname == "" || e.Name == "" {
+		return invalidf("entrant %s: surname and name are required", e.GUID)
+	}
+	if e.Birthday.IsZero() {
+		return invalidf("entrant %s: birthday is required", e.GUID)
+	}
+
+	uids := map[message.Datatype]map[string]bool{}
+	check := func(datatype message.Datatype, uid, guidEntrant string) error {
+		if uid == "" {
+			return invalidf("entrant %s: %s: UID is empty", e.GUID, datatype)
+		}
+		if guidEntrant != "" && guidEntrant != e.GUID {
+			return invalidf("entrant %s: %s %s: belongs to the entrant %s", e.GUID, datatype, uid, guidEntrant)
+		}
+		if uids[datatype] == nil {
+			uids[datatype] = map[string]bool{}
+		}
+		if uids[datatype][uid] {
+			return invalidf("entrant %s: %s %s: UID is duplicated", e.GUID, datatype, uid)
+		}
+		uids[datatype][uid] = true
+		return nil
+	}
+
+	for _, doc := range e.Identifications {
+		if err := check(message.DatatypeIdentification, doc.UID, doc.GUIDEntrant); err != nil {
+			return err
+		}
+	}
+	for _, doc := range e.Educations {
+		if err := check(message.DatatypeEducations, doc.UID, doc.GUIDEntrant); err != nil {
+			return err
+		}
+	}
+	for _, res := range e.EgeResults {
+		if err := check(message.DatatypeEge, res.UID, res.GUIDEntrant); err != nil {
+			return err
+		}
+		if res.Mark < 0 || res.Mark > 100 {
+			return invalidf("entrant %s: %s %s: mark %d is out of 0-100", e.GUID, message.DatatypeEge, res.UID, res.Mark)
+		}
+	}
+	for _, doc := range e.Benefits {
+		if _, ok := benefitElements[doc.Datatype]; !ok {
+			return invalidf("entrant %s: benefit document %s: unknown datatype %q", e.GUID, doc.UID, doc.Datatype)
+		}
+		if err := check(doc.Datatype, doc.UID, doc.GUIDEntrant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Plan Receive the steps adding the entrant first and then its documents, see DiffEntrants.
+func (e *Entrant) Plan() (Plan, error) {
+	return DiffEntrants(nil, e)
+}
+
+//Diff Receive the minimal steps changing the previous version of the entrant to this one.
+func (e *Entrant) Diff(prev *Entrant) (Plan, error) {
+	return DiffEntrants(prev, e)
+}
+
+//DiffEntrants Receive the minimal steps changing the entrant prev to next: the removed documents are removed first
+//and then the entrant, then the entrant and the documents are added and edited. The nil prev adds the entrant,
+//the nil next removes it. The entrant with the other GUID is replaced entirely.
+//The empty GUIDEntrant of the documents is set to the GUID of the entrant.
+func DiffEntrants(prev, next *Entrant) (Plan, error) {
+	if prev != nil {
+		if err := prev.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if next != nil {
+		if err := next.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if prev != nil && next != nil && prev.GUID != next.GUID {
+		remove, err := DiffEntrants(prev, nil)
+		if err != nil {
+			return nil, err
+		}
+		add, err := DiffEntrants(nil, next)
+		if err != nil {
+			return nil, err
+		}
+		return append(remove, add...), nil
+	}
+	return diffRecords(prev.records(), next.records())
+}
+
+//records Receive the records of the entrant: the entrant, its documents and the benefit documents by BenefitDatatypes.
+func (e *Entrant) records() []keyedGroup {
+	groups := []keyedGroup{
+		{datatype: message.DatatypeEntrants},
+		{datatype: message.DatatypeIdentification},
+		{datatype: message.DatatypeEducations},
+		{datatype: message.DatatypeEge},
+	}
+	for _, datatype := range BenefitDatatypes {
+		groups = append(groups, keyedGroup{datatype: datatype})
+	}
+	if e == nil {
+		return groups
+	}
+
+	groups[0].records = []keyedRecord{{uid: e.GUID, record: e}}
+	for _, doc := range e.Identifications {
+		doc.GUIDEntrant = e.GUID
+		groups[1].records = append(groups[1].records, keyedRecord{uid: doc.UID, record: doc})
+	}
+	for _, doc := range e.Educations {
+		doc.GUIDEntrant = e.GUID
+		groups[2].records = append(groups[2].records, keyedRecord{uid: doc.UID, record: doc})
+	}
+	for _, res := range e.EgeResults {
+		res.GUIDEntrant = e.GUID
+		groups[3].records = append(groups[3].records, keyedRecord{uid: res.UID, record: res})
+	}
+	for i, datatype := range BenefitDatatypes {
+		for _, doc := range e.Benefits {
+			if doc.Datatype == datatype {
+				doc.GUIDEntrant = e.GUID
+				groups[4+i].records = append(groups[4+i].records, keyedRecord{uid: doc.UID, record: doc})
+			}
+		}
+	}
+	return groups
+}
@@ -0,0 +1,299 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestEntrant_Validate(t *testing.T) {
+	birthday := NewDate(2003, time.May, 1)
+	tests := []struct {
+		name    string
+		entrant Entrant
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			entrant: Entrant{
+				GUID: "E1", Surname: "Иванов", Name: "Иван", Birthday: birthday,
+				Identifications: []Identification{{Document: Document{UID: "P1"}}},
+				Educations:      []Education{{Document: Document{UID: "A1", GUIDEntrant: "E1"}}},
+				EgeResults:      []EgeResult{{UID: "EGE1", Mark: 100}},
+				Benefits: []BenefitDocument{
+					{Datatype: message.DatatypeDisability, Document: Document{UID: "D1"}},
+					{Datatype: message.DatatypeOther, Document: Document{UID: "D1"}},
+				},
+			},
+		},
+		{name: "fail guid", entrant: Entrant{Surname: "Иванов", Name: "Иван", Birthday: birthday}, wantErr: true},
+		{name: "fail name", entrant: Entrant{GUID: "E1", Surname: "Иванов", Birthday: birthday}, wantErr: true},
+		{name: "fail birthday", entrant: Entrant{GUID: "E1", Surname: "Иванов", Name: "Иван"}, wantErr: true},
+		{
+			name: "fail uid",
+			entrant: Entrant{GUID: "E1", Surname: "Иванов", Name: "Иван", Birthday: birthday,
+				Identifications: []Identification{{Document: Document{DocNumber: "123456"}}}},
+			wantErr: true,
+		},
+		{
+			name: "fail other entrant",
+			entrant: Entrant{GUID: "E1", Surname: "Иванов", Name: "Иван", Birthday: birthday,
+				Educations: []Education{{Document: Document{UID: "A1", GUIDEntrant: "E2"}}}},
+			wantErr: true,
+		},
+		{
+			name: "fail mark",
+			entrant: Entrant{GUID: "E1", Surname: "Иванов", Name: "Иван", Birthday: birthday,
+				EgeResults: []EgeResult{{UID: "EGE1", Mark: 101}}},
+			wantErr: true,
+		},
+		{
+			name: "fail duplicated",
+			entrant: Entrant{GUID: "E1", Surname: "Иванов", Name: "Иван", Birthday: birthday,
+				Benefits: []BenefitDocument{
+					{Datatype: message.DatatypeDisability, Document: Document{UID: "D1"}},
+					{Datatype: message.DatatypeDisability, Document: Document{UID: "D1"}},
+				}},
+			wantErr: true,
+		},
+		{
+			name: "fail benefit datatype",
+			entrant: Entrant{GUID: "E1", Surname: "Иванов", Name: "Иван", Birthday: birthday,
+				Benefits: []BenefitDocument{{Datatype: message.DatatypeCampaign, Document: Document{UID: "C1"}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entrant.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestDiffEntrants(t *testing.T) {
+	entrant := func(update func(e *Entrant)) *Entrant {
+		e := &Entrant{
+			GUID:     "E1",
+			Surname:  "Иванов",
+			Name:     "Иван",
+			IDGender: 1,
+			Birthday: NewDate(2003, time.May, 1),
+			Identifications: []Identification{{
+				Document: Document{UID: "P1", IDDocumentType: 1, DocSeries: "4500", DocNumber: "123456", IssueDate: NewDate(2017, time.June, 1)},
+				Surname:  "Иванов",
+				Name:     "Иван",
+			}},
+			Educations: []Education{{
+				Document:         Document{UID: "A1", GUIDEntrant: "E1", IDDocumentType: 3, DocNumber: "0001"},
+				IDEducationLevel: 2,
+			}},
+			EgeResults: []EgeResult{{UID: "EGE1", IDSubject: 1, Mark: 87, Year: 2020}},
+			Benefits: []BenefitDocument{
+				{Datatype: message.DatatypeOther, Document: Document{UID: "O1", DocNumber: "3"}},
+				{Datatype: message.DatatypeDisability, Document: Document{UID: "D1", DocNumber: "1"}, IDCategory: 2},
+				{Datatype: message.DatatypeDisability, Document: Document{UID: "D2", DocNumber: "2"}, IDCategory: 1},
+			},
+		}
+		if update != nil {
+			update(e)
+		}
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		prev    *Entrant
+		next    *Entrant
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ok add",
+			next: entrant(nil),
+			want: []string{
+				"Add entrants E1",
+				"Add identification P1",
+				"Add educations A1",
+				"Add ege EGE1",
+				"Add disability D1",
+				"Add disability D2",
+				"Add other O1",
+			},
+		},
+		{
+			name: "ok equal",
+			prev: entrant(nil),
+			next: entrant(func(e *Entrant) { e.Identifications[0].GUIDEntrant = "E1" }),
+		},
+		{
+			name: "ok edit entrant",
+			prev: entrant(nil),
+			next: entrant(func(e *Entrant) { e.Phone = "+7 900 000-00-00" }),
+			want: []string{"Edit entrants E1"},
+		},
+		{
+			name: "ok documents",
+			prev: entrant(nil),
+			next: entrant(func(e *Entrant) {
+				e.Identifications[0].DocNumber = "654321"
+				e.EgeResults = nil
+				e.Benefits = append(e.Benefits[1:], BenefitDocument{Datatype: message.DatatypeOrphans, Document: Document{UID: "OR1", DocNumber: "4"}})
+			}),
+			want: []string{
+				"Remove other O1",
+				"Remove ege EGE1",
+				"Edit identification P1",
+				"Add orphans OR1",
+			},
+		},
+		{
+			name: "ok remove",
+			prev: entrant(nil),
+			want: []string{
+				"Remove other O1",
+				"Remove disability D1",
+				"Remove disability D2",
+				"Remove ege EGE1",
+				"Remove educations A1",
+				"Remove identification P1",
+				"Remove entrants E1",
+			},
+		},
+		{
+			name: "ok replace",
+			prev: entrant(func(e *Entrant) { e.Educations, e.EgeResults, e.Benefits = nil, nil, nil }),
+			next: entrant(func(e *Entrant) {
+				e.GUID = "E2"
+				e.Identifications[0].UID = "P2"
+				e.Educations, e.EgeResults, e.Benefits = nil, nil, nil
+			}),
+			want: []string{
+				"Remove identification P1",
+				"Remove entrants E1",
+				"Add entrants E2",
+				"Add identification P2",
+			},
+		},
+		{
+			name:    "fail invalid",
+			next:    entrant(func(e *Entrant) { e.Surname = "" }),
+			wantErr: true,
+		},
+		{
+			name:    "fail invalid prev",
+			prev:    entrant(func(e *Entrant) { e.GUID = "" }),
+			next:    entrant(nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := DiffEntrants(tt.prev, tt.next)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffEntrants() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := planSummary(t, plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffEntrants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	plan, err := entrant(nil).Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<PackageData><Identification><UID>P1</UID><GUIDEntrant>E1</GUIDEntrant><IdDocumentType>1</IdDocumentType>" +
+		"<DocSeries>4500</DocSeries><DocNumber>123456</DocNumber><IssueDate>2017-06-01</IssueDate>" +
+		"<Surname>Иванов</Surname><Name>Иван</Name></Identification></PackageData>"
+	if string(plan[1].Data) != want {
+		t.Errorf("Plan() identification = %s, want %s", plan[1].Data, want)
+	}
+	if got := string(plan[4].Data); strings.Count(got, "<Disability>") != 2 || !strings.Contains(got, "<IdCategory>2</IdCategory>") {
+		t.Errorf("Plan() disability = %s", got)
+	}
+}
+
+func TestBenefitDocument_UnmarshalXML(t *testing.T) {
+	pkg := struct {
+		Benefits []BenefitDocument `xml:",any"`
+	}{}
+	data := "<PackageData><Orphan><UID>O1</UID><GUIDEntrant>E1</GUIDEntrant><DocNumber>1</DocNumber><IdCategory>3</IdCategory></Orphan></PackageData>"
+	if err := xml.Unmarshal([]byte(data), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	want := []BenefitDocument{{
+		Datatype:   message.DatatypeOrphans,
+		Document:   Document{UID: "O1", GUIDEntrant: "E1", DocNumber: "1"},
+		IDCategory: 3,
+	}}
+	if !reflect.DeepEqual(pkg.Benefits, want) {
+		t.Errorf("UnmarshalXML() = %+v, want %+v", pkg.Benefits, want)
+	}
+}
+
+func TestDiffEntrants_send(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+	entrant := &Entrant{
+		GUID:            "E1",
+		Surname:         "Иванов",
+		Name:            "Иван",
+		IDGender:        1,
+		Birthday:        NewDate(2003, time.May, 1),
+		Identifications: []Identification{{Document: Document{UID: "P1", IDDocumentType: 1, DocNumber: "123456"}, Surname: "Иванов", Name: "Иван"}},
+		Educations:      []Education{{Document: Document{UID: "A1", IDDocumentType: 3, DocNumber: "0001"}, IDEducationLevel: 2}},
+		EgeResults:      []EgeResult{{UID: "EGE1", IDSubject: 1, Mark: 87, Year: 2020}},
+		Benefits:        []BenefitDocument{{Datatype: message.DatatypeDisability, Document: Document{UID: "D1", DocNumber: "1"}, IDCategory: 2}},
+	}
+
+	edited := *entrant
+	edited.Phone = "+7 900 000-00-00"
+	edited.EgeResults = nil
+
+	var plan Plan
+	for _, diff := range []struct{ prev, next *Entrant }{{next: entrant}, {prev: entrant, next: &edited}, {prev: &edited}} {
+		steps, err := DiffEntrants(diff.prev, diff.next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plan = append(plan, steps...)
+	}
+	for _, msg := range plan.Messages(ts.Crypto) {
+		if _, err := ts.Client.Send(ctx, msg).Data(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, msg := range ts.Queue() {
+		res := test_server_epgu.Result{}
+		if err := xml.Unmarshal(msg.Response, &res); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Result {
+			t.Errorf("%s %s: %v", msg.Action, msg.Datatype, res.Errors)
+		}
+	}
+	if keys := ts.Entities(message.DatatypeEntrants); len(keys) != 0 {
+		t.Errorf("Entities() = %v, want empty", keys)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+type exchangeOptions struct {
+	interval time.Duration
+	attempts int
+}
+
+type ExchangeOption func(*exchangeOptions)
+
+//SetPollInterval To set the interval between the requests of the result of the message, by default 1 second.
+func SetPollInterval(interval time.Duration) ExchangeOption {
+	return func(o *exchangeOptions) {
+		o.interval = interval
+	}
+}
+
+//SetPollAttempts To set the number of the requests of the result of the message, by default 30.
+func SetPollAttempts(attempts int) ExchangeOption {
+	return func(o *exchangeOptions) {
+		o.attempts = attempts
+	}
+}
+
+func newExchangeOptions(opts []ExchangeOption) exchangeOptions {
+	o := exchangeOptions{interval: time.Second, attempts: 30}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+//Exchange Send the step, wait for its result in the queue and confirm it,
+//the result rejected by the service is returned with the error wrapping ErrRejected.
+//Supports the following options: SetPollInterval, SetPollAttempts.
+func Exchange(ctx context.Context, client sspvo.Client, crypto sspvo.Crypto, step Step, opts ...ExchangeOption) (*Info, error) {
+	o := newExchangeOptions(opts)
+
+	data, err := client.Send(ctx, step.Message(crypto)).Data()
+	if err != nil {
+		return nil, fmt.Errorf("model: %s %s: %w", step.Action, step.Datatype, err)
+	}
+	idJWT, err := newMessageIDJWT(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var info *Info
+	for attempt := 0; info == nil; attempt++ {
+		data, err = client.Send(ctx, message.NewInfoMessage(crypto, idJWT)).Data()
+		if err == nil {
+			info, err = ParseInfo(data)
+		}
+		switch {
+		case errors.Is(err, sspvo.ErrBadSign):
+			return nil, fmt.Errorf("model: IDJWT %d: %w", idJWT, err)
+		case err == nil:
+		case attempt+1 >= o.attempts:
+			return nil, fmt.Errorf("model: IDJWT %d: result not received: %w", idJWT, err)
+		default:
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("model: IDJWT %d: %w", idJWT, ctx.Err())
+			case <-time.After(o.interval):
+			}
+		}
+	}
+
+	if _, err = client.Send(ctx, message.NewConfirmMessage(crypto, idJWT)).Data(); err != nil {
+		return info, fmt.Errorf("model: IDJWT %d: confirm: %w", idJWT, err)
+	}
+	return info, info.Err()
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestExchange(t *testing.T) {
+	add := Step{Action: message.ActionAdd, Datatype: message.DatatypeSubdivisionOrg,
+		Data: []byte("<PackageData><SubdivisionOrg><UID>S1</UID></SubdivisionOrg></PackageData>")}
+	tests := []struct {
+		name      string
+		fault     *test_server_epgu.Fault
+		wantQueue int
+		wantErr   error
+	}{
+		{name: "ok"},
+		{name: "ok retry", fault: &test_server_epgu.Fault{Status: 503, Times: 2}},
+		{name: "fail bad sign", fault: &test_server_epgu.Fault{InvalidSignature: true}, wantQueue: 1, wantErr: sspvo.ErrBadSign},
+		{name: "fail attempts", fault: &test_server_epgu.Fault{Status: 503}, wantQueue: 1, wantErr: errors.New("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := test_server_epgu.Start(t)
+			if tt.fault != nil {
+				ts.SetFault(test_server_epgu.EndpointTokenInfo, *tt.fault)
+			}
+			info, err := Exchange(context.Background(), ts.Client, ts.Crypto, add,
+				SetPollInterval(time.Millisecond), SetPollAttempts(3))
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("Exchange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == sspvo.ErrBadSign && !errors.Is(err, sspvo.ErrBadSign) {
+				t.Errorf("Exchange() error = %v, want %v", err, sspvo.ErrBadSign)
+			}
+			if err == nil && (info.Action != message.ActionAdd || info.Datatype != message.DatatypeSubdivisionOrg) {
+				t.Errorf("Exchange() = %+v", info)
+			}
+			if queue := ts.Queue(); len(queue) != tt.wantQueue {
+				t.Errorf("Queue() = %+v, want %d", queue, tt.wantQueue)
+			}
+		})
+	}
+
+	ts := test_server_epgu.Start(t)
+	if _, err := Exchange(context.Background(), ts.Client, ts.Crypto, add); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Exchange(context.Background(), ts.Client, ts.Crypto, add); !errors.Is(err, ErrRejected) {
+		t.Errorf("Exchange() of the duplicate error = %v, want %v", err, ErrRejected)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+var (
+	//ErrRejected The service did not apply the message.
+	ErrRejected = errors.New("model: rejected")
+	//ErrNotFound The service rejected the message because the records are not found, it wraps ErrRejected.
+	ErrNotFound = fmt.Errorf("%w: not found", ErrRejected)
+)
+
+//InfoError Error of the processing of the message by the service.
+type InfoError struct {
+	Code        string
+	Description string
+	UIDEpgu     string
+}
+
+func (e InfoError) String() string {
+	if e.Code == "" {
+		return e.Description
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+//NotFound Check that the error reports the records not found: "Запись ... не найдена", "Заявление не найдено.".
+func (e InfoError) NotFound() bool {
+	return strings.Contains(strings.ToLower(e.Description), "не найден")
+}
+
+//Info Result of the processing of the message received from the queue by InfoMessage.
+type Info struct {
+	IDJWT    int
+	Action   message.Action
+	Datatype message.Datatype
+	//Payload XML of the payload of the ResponseToken.
+	Payload []byte
+	Errors  []InfoError
+}
+
+//Err Receive the error wrapping ErrRejected if the service returned the errors,
+//ErrNotFound if every error reports the records not found.
+func (i *Info) Err() error {
+	if len(i.Errors) == 0 {
+		return nil
+	}
+	texts := make([]string, 0, len(i.Errors))
+	notFound := true
+	for _, e := range i.Errors {
+		texts = append(texts, e.String())
+		notFound = notFound && e.NotFound()
+	}
+	err := ErrRejected
+	if notFound {
+		err = ErrNotFound
+	}
+	return fmt.Errorf("%w: IDJWT %d: %s", err, i.IDJWT, strings.Join(texts, "; "))
+}
+
+//PackageData Receive the PackageData of the payload, the records requested by message.ActionGet.
+func (i *Info) PackageData() ([]byte, error) {
+	root := struct {
+		XMLName xml.Name
+		Inner   []byte `xml:",innerxml"`
+		Package *struct {
+			Inner []byte `xml:",innerxml"`
+		} `xml:"PackageData"`
+	}{}
+	if err := xml.Unmarshal(i.Payload, &root); err != nil {
+		return nil, fmt.Errorf("model: info: %w", err)
+	}
+	switch {
+	case root.XMLName.Local == "PackageData":
+		return append(append([]byte("<PackageData>"), root.Inner...), "</PackageData>"...), nil
+	case root.Package != nil:
+		return append(append([]byte("<PackageData>"), root.Package.Inner...), "</PackageData>"...), nil
+	}
+	return nil, fmt.Errorf("model: info: IDJWT %d: PackageData not found", i.IDJWT)
+}
+
+type infoError struct {
+	Code        string `xml:"ErrorCode"`
+	Description string `xml:"ErrorDescription"`
+	UIDEpgu     string `xml:"UIDEpgu"`
+	Text        string `xml:",chardata"`
+}
+
+//ParseInfo Read the response on InfoMessage. The service reports the errors by the Error elements of the payload,
+//the test server by Result and Errors.
+func ParseInfo(data []byte) (*Info, error) {
+	resp := struct {
+		ResponseToken string `json:"ResponseToken"`
+	}{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("model: info: %w", err)
+	}
+	parts := strings.Split(strings.TrimSpace(resp.ResponseToken), ".")
+	if len(parts) < 2 {
+		return nil, errors.New("model: info: ResponseToken has no payload")
+	}
+
+	header := struct {
+		IDJWT    interface{} `json:"IDJWT"`
+		Action   string      `json:"action"`
+		DataType string      `json:"data_type"`
+	}{}
+	headerData, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("model: info: %w", err)
+	}
+	if err = json.Unmarshal(headerData, &header); err != nil {
+		return nil, fmt.Errorf("model: info: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("model: info: %w", err)
+	}
+
+	info := &Info{
+		Action:   message.Action(header.Action),
+		Datatype: message.Datatype(header.DataType),
+		Payload:  payload,
+	}
+	if header.IDJWT != nil {
+		if info.IDJWT, err = idJWTValue(header.IDJWT); err != nil {
+			return nil, fmt.Errorf("model: info: %w", err)
+		}
+	}
+
+	body := struct {
+		EntityType string      `xml:"EntityType"`
+		Action     string      `xml:"Action"`
+		DataType   string      `xml:"DataType"`
+		Result     *bool       `xml:"Result"`
+		Error      []infoError `xml:"Error"`
+		Errors     []infoError `xml:"Errors>Error"`
+	}{}
+	if err = xml.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("model: info: %w", err)
+	}
+	if body.EntityType != "" && !info.Datatype.IsValid() {
+		info.Datatype = message.Datatype(body.EntityType)
+	}
+	if body.DataType != "" && !info.Datatype.IsValid() {
+		info.Datatype = message.Datatype(body.DataType)
+	}
+	if info.Action == "" {
+		info.Action = message.Action(body.Action)
+	}
+	for _, e := range append(body.Error, body.Errors...) {
+		if e.Description == "" {
+			e.Description = strings.TrimSpace(e.Text)
+		}
+		info.Errors = append(info.Errors, InfoError{Code: e.Code, Description: e.Description, UIDEpgu: e.UIDEpgu})
+	}
+	if body.Result != nil && !*body.Result && len(info.Errors) == 0 {
+		info.Errors = append(info.Errors, InfoError{Description: "result is false"})
+	}
+	return info, nil
+}
+
+//newMessageIDJWT Read the IDJWT of the message accepted by the service.
+func newMessageIDJWT(data []byte) (int, error) {
+	resp := struct {
+		IDJWT interface{} `json:"IDJWT"`
+	}{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, fmt.Errorf("model: %w", err)
+	}
+	if resp.IDJWT == nil {
+		return 0, errors.New("model: IDJWT not found")
+	}
+	idJWT, err := idJWTValue(resp.IDJWT)
+	if err != nil {
+		return 0, fmt.Errorf("model: %w", err)
+	}
+	return idJWT, nil
+}
+
+//idJWTValue Receive the IDJWT sent by the service as the number or the string.
+func idJWTValue(v interface{}) (int, error) {
+	switch value := v.(type) {
+	case float64:
+		return int(value), nil
+	case string:
+		idJWT, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("IDJWT: %w", err)
+		}
+		return idJWT, nil
+	}
+	return 0, fmt.Errorf("IDJWT: unexpected value %v", v)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func testInfoData(header, payload string) []byte {
+	token := base64.StdEncoding.EncodeToString([]byte(header)) + "." +
+		base64.StdEncoding.EncodeToString([]byte(payload)) + ".c2lnbg=="
+	return []byte(`{"ResponseToken":"` + token + `"}`)
+}
+
+func TestParseInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		want         *Info
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{
+			name: "ok service error",
+			data: testInfoData(`{"IDJWT":1386171,"data_type":"Error"}`,
+				"<PackageData><EntityType>edit_application_status</EntityType><Error><ErrorCode>4056</ErrorCode>"+
+					"<ErrorDescription>Заявление не найдено.</ErrorDescription><UIDEpgu>865633484</UIDEpgu></Error></PackageData>"),
+			want: &Info{
+				IDJWT:    1386171,
+				Datatype: message.DatatypeEditApplicationStatus,
+				Errors:   []InfoError{{Code: "4056", Description: "Заявление не найдено.", UIDEpgu: "865633484"}},
+			},
+			wantNotFound: true,
+		},
+		{
+			name: "ok not found and other",
+			data: testInfoData(`{"IDJWT":"4","action":"Get","data_type":"campaign"}`,
+				"<Response><IDJWT>4</IDJWT><Result>false</Result><Errors><Error>Запись Campaign с UID C1 не найдена</Error>"+
+					"<Error>Нет доступа к кампании</Error></Errors></Response>"),
+			want: &Info{
+				IDJWT:    4,
+				Action:   message.ActionGet,
+				Datatype: message.DatatypeCampaign,
+				Errors:   []InfoError{{Description: "Запись Campaign с UID C1 не найдена"}, {Description: "Нет доступа к кампании"}},
+			},
+		},
+		{
+			name: "ok result false",
+			data: testInfoData(`{"IDJWT":"2","action":"Add","data_type":"campaign"}`,
+				"<Response><IDJWT>2</IDJWT><Result>false</Result><Errors><Error>Запись уже существует</Error></Errors></Response>"),
+			want: &Info{
+				IDJWT:    2,
+				Action:   message.ActionAdd,
+				Datatype: message.DatatypeCampaign,
+				Errors:   []InfoError{{Description: "Запись уже существует"}},
+			},
+		},
+		{
+			name: "ok",
+			data: testInfoData(`{"IDJWT":3,"action":"Edit","data_type":"edit_application_status"}`,
+				"<Response><IDJWT>3</IDJWT><Result>true</Result></Response>"),
+			want: &Info{IDJWT: 3, Action: message.ActionEdit, Datatype: message.DatatypeEditApplicationStatus},
+		},
+		{name: "fail json", data: []byte("x"), wantErr: true},
+		{name: "fail token", data: []byte(`{"ResponseToken":"x"}`), wantErr: true},
+		{name: "fail idjwt", data: testInfoData(`{"IDJWT":"x"}`, "<Response/>"), wantErr: true},
+		{name: "fail payload", data: testInfoData(`{"IDJWT":1}`, "<Response>"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInfo(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got.Payload = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseInfo() = %+v, want %+v", got, tt.want)
+			}
+			if err = got.Err(); (err != nil) != (len(tt.want.Errors) > 0) || (err != nil && !errors.Is(err, ErrRejected)) {
+				t.Errorf("Err() = %v", err)
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("Err() = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+//Package model Typed records of the service and the planners of the messages that create, update or remove them.
+package model
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//ErrInvalid The record can not be sent to the service.
+var ErrInvalid = errors.New("model: invalid")
+
+//DateLayout Layout of the dates in the PackageData.
+const DateLayout = "2006-01-02"
+
+//Date Date of the PackageData, the zero date is omitted.
+type Date struct {
+	time.Time
+}
+
+//NewDate Creating a new Date.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(d.Format(DateLayout), start)
+}
+
+//UnmarshalXML Read the date, the service also returns the dates with the time.
+func (d *Date) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		*d = Date{}
+		return nil
+	}
+	for _, layout := range []string{DateLayout, "2006-01-02T15:04:05", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			*d = Date{Time: t}
+			return nil
+		}
+	}
+	return fmt.Errorf("model: bad date %q", s)
+}
+
+//Step One message of the plan: the action with the records of the datatype.
+type Step struct {
+	Action   message.Action
+	Datatype message.Datatype
+	Data     []byte
+}
+
+//Message Receive the ActionMessage of the step.
+func (s Step) Message(crypto sspvo.Crypto) *message.ActionMessage {
+	return message.NewActionMessage(crypto, s.Action, s.Datatype, s.Data)
+}
+
+//Plan Steps to be sent in the order, each step depends on the records of the previous ones.
+type Plan []Step
+
+//Messages Receive the ActionMessage of every step in the order of the plan.
+func (p Plan) Messages(crypto sspvo.Crypto) []*message.ActionMessage {
+	msgs := make([]*message.ActionMessage, 0, len(p))
+	for _, step := range p {
+		msgs = append(msgs, step.Message(crypto))
+	}
+	return msgs
+}
+
+//recordGroup Records of the datatype sent by one step.
+type recordGroup struct {
+	datatype message.Datatype
+	records  []interface{}
+}
+
+//planGroups Receive the steps of the action for the groups in their order, the empty groups are skipped.
+func planGroups(action message.Action, groups []recordGroup) (Plan, error) {
+	var plan Plan
+	for _, group := range groups {
+		if len(group.records) == 0 {
+			continue
+		}
+		data, err := packageData(group.records...)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, Step{Action: action, Datatype: group.datatype, Data: data})
+	}
+	return plan, nil
+}
+
+//keyedRecord Record with its UID and the reference to the parent record: set by the caller and expected one.
+type keyedRecord struct {
+	uid        string
+	parent     string
+	wantParent string
+	record     interface{}
+}
+
+//keyedGroup Records of the datatype compared by UID.
+type keyedGroup struct {
+	datatype message.Datatype
+	records  []keyedRecord
+}
+
+//diffRecords Receive the steps changing the records prev to next, both are in the order of the dependencies of the datatypes.
+//The records are removed in the reverse order, then added and edited in the order, the equal records are skipped.
+func diffRecords(prev, next []keyedGroup) (Plan, error) {
+	var removes []recordGroup
+	var changes Plan
+	for i := range next {
+		prevRecords := map[string][]byte{}
+		for _, r := range prev[i].records {
+			data, err := xml.Marshal(r.record)
+			if err != nil {
+				return nil, fmt.Errorf("model: %w", err)
+			}
+			prevRecords[r.uid] = data
+		}
+
+		nextUIDs := map[string]bool{}
+		added := recordGroup{datatype: next[i].datatype}
+		edited := recordGroup{datatype: next[i].datatype}
+		for _, r := range next[i].records {
+			nextUIDs[r.uid] = true
+			prevData, ok := prevRecords[r.uid]
+			if !ok {
+				added.records = append(added.records, r.record)
+				continue
+			}
+			data, err := xml.Marshal(r.record)
+			if err != nil {
+				return nil, fmt.Errorf("model: %w", err)
+			}
+			if !bytes.Equal(data, prevData) {
+				edited.records = append(edited.records, r.record)
+			}
+		}
+		for _, step := range []struct {
+			action message.Action
+			group  recordGroup
+		}{{action: message.ActionAdd, group: added}, {action: message.ActionEdit, group: edited}} {
+			steps, err := planGroups(step.action, []recordGroup{step.group})
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, steps...)
+		}
+
+		removed := recordGroup{datatype: prev[i].datatype}
+		for _, r := range prev[i].records {
+			if !nextUIDs[r.uid] {
+				removed.records = append(removed.records, r.record)
+			}
+		}
+		removes = append([]recordGroup{removed}, removes...)
+	}
+
+	plan, err := planGroups(message.ActionRemove, removes)
+	if err != nil {
+		return nil, err
+	}
+	return append(plan, changes...), nil
+}
+
+//packageData Marshal the records to the PackageData.
+func packageData(records ...interface{}) ([]byte, error) {
+	buf := bytes.NewBufferString("<PackageData>")
+	for _, record := range records {
+		data, err := xml.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("model: %w", err)
+		}
+		buf.Write(data)
+	}
+	buf.WriteString("</PackageData>")
+	return buf.Bytes(), nil
+}
+
+//decodePackageData Read the records of the PackageData into the slice pointed by records,
+//the element of the record must match the XMLName of the type.
+func decodePackageData(data []byte, records interface{}) error {
+	slice := reflect.ValueOf(records).Elem()
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("model: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				depth++
+				continue
+			}
+			record := reflect.New(slice.Type().Elem())
+			if err = dec.DecodeElement(record.Interface(), &t); err != nil {
+				return fmt.Errorf("model: %w", err)
+			}
+			slice.Set(reflect.Append(slice, record.Elem()))
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+//invalidf Receive the error of the invalid record.
+func invalidf(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func TestDate_XML(t *testing.T) {
+	type record struct {
+		XMLName xml.Name `xml:"Record"`
+		Date    Date     `xml:"Date"`
+	}
+	tests := []struct {
+		name    string
+		data    string
+		want    Date
+		wantXML string
+		wantErr bool
+	}{
+		{name: "ok", data: "<Record><Date>2020-07-01</Date></Record>", want: NewDate(2020, time.July, 1), wantXML: "<Record><Date>2020-07-01</Date></Record>"},
+		{name: "ok time", data: "<Record><Date>2020-07-01T10:00:00</Date></Record>", want: Date{Time: time.Date(2020, time.July, 1, 10, 0, 0, 0, time.UTC)}, wantXML: "<Record><Date>2020-07-01</Date></Record>"},
+		{name: "ok empty", data: "<Record><Date></Date></Record>", wantXML: "<Record></Record>"},
+		{name: "fail", data: "<Record><Date>01.07.2020</Date></Record>", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := record{}
+			err := xml.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Date.Equal(tt.want.Time) {
+				t.Errorf("Unmarshal() = %v, want %v", got.Date, tt.want)
+			}
+			data, err := xml.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.wantXML {
+				t.Errorf("Marshal() = %s, want %s", data, tt.wantXML)
+			}
+		})
+	}
+}
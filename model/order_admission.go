@@ -0,0 +1,222 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//OrderAdmission Order of the admission, datatype message.DatatypeOrderAdmission.
+type OrderAdmission struct {
+	XMLName                xml.Name           `xml:"OrderAdmission"`
+	UID                    string             `xml:"UID"`
+	UIDCampaign            string             `xml:"UIDCampaign,omitempty"`
+	IDOrderAdmissionType   int                `xml:"IdOrderAdmissionType"`
+	IDOrderAdmissionStatus int                `xml:"IdOrderAdmissionStatus"`
+	OrderName              string             `xml:"OrderName,omitempty"`
+	OrderNumber            string             `xml:"OrderNumber"`
+	OrderDate              Date               `xml:"OrderDate"`
+	PublishDate            Date               `xml:"PublishDate"`
+	Applications           []OrderApplication `xml:"Applications>Application"`
+}
+
+//OrderApplication Application included to the order.
+type OrderApplication struct {
+	UID                 string `xml:"UID"`
+	UIDCompetitiveGroup string `xml:"UIDCompetitiveGroup,omitempty"`
+}
+
+//OrderStatuses IDs of the statuses of CLSOrderAdmissionStatuses for the lifecycle of the order.
+type OrderStatuses struct {
+	New       int
+	Published int
+	Canceled  int
+}
+
+type orderAdmissionOptions struct {
+	statuses *OrderStatuses
+	known    []string
+}
+
+type OrderAdmissionOption func(*orderAdmissionOptions)
+
+//SetOrderStatuses To set the IDs of the statuses of the lifecycle of the order, the classifier of the service
+//does not mark them so the option is required.
+func SetOrderStatuses(statuses OrderStatuses) OrderAdmissionOption {
+	return func(o *orderAdmissionOptions) {
+		o.statuses = &statuses
+	}
+}
+
+//SetKnownApplications To set the UID of the applications known to the service, see AddKnown.
+func SetKnownApplications(uids ...string) OrderAdmissionOption {
+	return func(o *orderAdmissionOptions) {
+		o.known = append(o.known, uids...)
+	}
+}
+
+//OrderAdmissionBuilder Builder of the orders checking them against the classifiers and the known applications.
+type OrderAdmissionBuilder struct {
+	types     *Classifier
+	statuses  *Classifier
+	lifecycle OrderStatuses
+	known     map[string]bool
+}
+
+//NewOrderAdmissionBuilder Creating a new OrderAdmissionBuilder for the classifiers CLSOrderAdmissionTypes and CLSOrderAdmissionStatuses,
+//the orders may include only the applications with the known UID. Supports the following options: SetOrderStatuses, SetKnownApplications.
+func NewOrderAdmissionBuilder(types, statuses *Classifier, opts ...OrderAdmissionOption) (*OrderAdmissionBuilder, error) {
+	o := orderAdmissionOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.statuses == nil {
+		return nil, errors.New("model: order admission: statuses not set")
+	}
+	for _, id := range []int{o.statuses.New, o.statuses.Published, o.statuses.Canceled} {
+		if _, ok := statuses.Item(id); !ok {
+			return nil, fmt.Errorf("model: order admission: status %d not found in %s", id, statuses.CLS)
+		}
+	}
+
+	b := &OrderAdmissionBuilder{types: types, statuses: statuses, lifecycle: *o.statuses, known: map[string]bool{}}
+	b.AddKnown(o.known...)
+	return b, nil
+}
+
+//LoadOrderAdmissionBuilder Creating a new OrderAdmissionBuilder for the classifiers received from the service.
+func LoadOrderAdmissionBuilder(ctx context.Context, client sspvo.Client, opts ...OrderAdmissionOption) (*OrderAdmissionBuilder, error) {
+	types, err := LoadClassifier(ctx, client, message.CLSOrderAdmissionTypes)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := LoadClassifier(ctx, client, message.CLSOrderAdmissionStatuses)
+	if err != nil {
+		return nil, err
+	}
+	return NewOrderAdmissionBuilder(types, statuses, opts...)
+}
+
+//AddKnown Add the UID of the applications known to the service.
+func (b *OrderAdmissionBuilder) AddKnown(uids ...string) {
+	for _, uid := range uids {
+		b.known[uid] = true
+	}
+}
+
+//New Creating a new order of the type with the status New of OrderStatuses.
+func (b *OrderAdmissionBuilder) New(uid string, typeID int, number string, date Date, applications ...OrderApplication) (OrderAdmission, error) {
+	order := OrderAdmission{
+		UID:                    uid,
+		IDOrderAdmissionType:   typeID,
+		IDOrderAdmissionStatus: b.lifecycle.New,
+		OrderNumber:            number,
+		OrderDate:              date,
+		Applications:           applications,
+	}
+	if err := b.Validate(order); err != nil {
+		return OrderAdmission{}, err
+	}
+	return order, nil
+}
+
+//Validate Check the order: the type and the status are actual, every application is known and included once.
+func (b *OrderAdmissionBuilder) Validate(order OrderAdmission) error {
+	if order.UID == "" {
+		return invalidf("order admission: UID is empty")
+	}
+	if order.OrderNumber == "" || order.OrderDate.IsZero() {
+		return invalidf("order admission %s: number and date are required", order.UID)
+	}
+	if item, ok := b.types.Item(order.IDOrderAdmissionType); !ok || !item.Actual {
+		return invalidf("order admission %s: type %d is unknown or not actual", order.UID, order.IDOrderAdmissionType)
+	}
+	status, ok := b.statuses.Item(order.IDOrderAdmissionStatus)
+	if !ok || !status.Actual {
+		return invalidf("order admission %s: status %d is unknown or not actual", order.UID, order.IDOrderAdmissionStatus)
+	}
+	if status.ID == b.lifecycle.Published && order.PublishDate.IsZero() {
+		return invalidf("order admission %s: publish date is required", order.UID)
+	}
+	if len(order.Applications) == 0 {
+		return invalidf("order admission %s: applications are empty", order.UID)
+	}
+	seen := map[string]bool{}
+	for _, app := range order.Applications {
+		if !b.known[app.UID] {
+			return invalidf("order admission %s: application %q is unknown", order.UID, app.UID)
+		}
+		if seen[app.UID] {
+			return invalidf("order admission %s: application %s is duplicated", order.UID, app.UID)
+		}
+		seen[app.UID] = true
+	}
+	return nil
+}
+
+//Add Receive the step adding the new order.
+func (b *OrderAdmissionBuilder) Add(order OrderAdmission) (Step, error) {
+	if err := b.expectStatus(order, b.lifecycle.New); err != nil {
+		return Step{}, err
+	}
+	return b.step(message.ActionAdd, order)
+}
+
+//Publish Receive the order with the status Published and the step editing it, only the new order is published.
+func (b *OrderAdmissionBuilder) Publish(order OrderAdmission, date Date) (OrderAdmission, Step, error) {
+	if err := b.expectStatus(order, b.lifecycle.New); err != nil {
+		return OrderAdmission{}, Step{}, err
+	}
+	order.PublishDate = date
+	return b.transit(order, b.lifecycle.Published)
+}
+
+//Cancel Receive the order with the status Canceled and the step editing it, the new or published order is canceled.
+func (b *OrderAdmissionBuilder) Cancel(order OrderAdmission) (OrderAdmission, Step, error) {
+	if err := b.expectStatus(order, b.lifecycle.New, b.lifecycle.Published); err != nil {
+		return OrderAdmission{}, Step{}, err
+	}
+	return b.transit(order, b.lifecycle.Canceled)
+}
+
+func (b *OrderAdmissionBuilder) transit(order OrderAdmission, status int) (OrderAdmission, Step, error) {
+	order.IDOrderAdmissionStatus = status
+	step, err := b.step(message.ActionEdit, order)
+	if err != nil {
+		return OrderAdmission{}, Step{}, err
+	}
+	return order, step, nil
+}
+
+//expectStatus Check that the order has one of the statuses.
+func (b *OrderAdmissionBuilder) expectStatus(order OrderAdmission, statuses ...int) error {
+	for _, status := range statuses {
+		if order.IDOrderAdmissionStatus == status {
+			return nil
+		}
+	}
+	return invalidf("order admission %s: status %d, expected %v", order.UID, order.IDOrderAdmissionStatus, statuses)
+}
+
+func (b *OrderAdmissionBuilder) step(action message.Action, order OrderAdmission) (Step, error) {
+	if err := b.Validate(order); err != nil {
+		return Step{}, err
+	}
+	data, err := packageData(order)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Action: action, Datatype: message.DatatypeOrderAdmission, Data: data}, nil
+}
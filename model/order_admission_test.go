@@ -0,0 +1,180 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+//orderStatuses Statuses of the lifecycle of the order in the fixture OrderAdmissionStatuses of the test server.
+var orderStatuses = SetOrderStatuses(OrderStatuses{New: 1, Published: 2, Canceled: 3})
+
+func TestNewOrderAdmissionBuilder(t *testing.T) {
+	types := testClassifier(t, message.CLSOrderAdmissionTypes)
+	statuses := testClassifier(t, message.CLSOrderAdmissionStatuses)
+
+	tests := []struct {
+		name    string
+		opts    []OrderAdmissionOption
+		wantErr bool
+	}{
+		{name: "ok", opts: []OrderAdmissionOption{orderStatuses, SetKnownApplications("A1")}},
+		{name: "fail statuses not set", opts: []OrderAdmissionOption{SetKnownApplications("A1")}, wantErr: true},
+		{name: "fail unknown status", opts: []OrderAdmissionOption{SetOrderStatuses(OrderStatuses{New: 1, Published: 2, Canceled: 100})}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewOrderAdmissionBuilder(types, statuses, tt.opts...); (err != nil) != tt.wantErr {
+				t.Errorf("NewOrderAdmissionBuilder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrderAdmissionBuilder_Validate(t *testing.T) {
+	types := testClassifier(t, message.CLSOrderAdmissionTypes)
+	statuses := testClassifier(t, message.CLSOrderAdmissionStatuses)
+	b, err := NewOrderAdmissionBuilder(types, statuses, orderStatuses, SetKnownApplications("A1", "A2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := b.New("O1", 1, "15-с", NewDate(2020, time.August, 3),
+		OrderApplication{UID: "A1"}, OrderApplication{UID: "A2", UIDCompetitiveGroup: "G1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		update  func(o *OrderAdmission)
+		wantErr bool
+	}{
+		{name: "ok", update: func(o *OrderAdmission) {}},
+		{name: "fail uid", update: func(o *OrderAdmission) { o.UID = "" }, wantErr: true},
+		{name: "fail number", update: func(o *OrderAdmission) { o.OrderNumber = "" }, wantErr: true},
+		{name: "fail type", update: func(o *OrderAdmission) { o.IDOrderAdmissionType = 100 }, wantErr: true},
+		{name: "fail status", update: func(o *OrderAdmission) { o.IDOrderAdmissionStatus = 100 }, wantErr: true},
+		{name: "fail published without date", update: func(o *OrderAdmission) { o.IDOrderAdmissionStatus = 2 }, wantErr: true},
+		{name: "fail empty", update: func(o *OrderAdmission) { o.Applications = nil }, wantErr: true},
+		{name: "fail unknown application", update: func(o *OrderAdmission) { o.Applications[1].UID = "A3" }, wantErr: true},
+		{name: "fail duplicated application", update: func(o *OrderAdmission) { o.Applications[1].UID = "A1" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := order
+			o.Applications = append([]OrderApplication(nil), order.Applications...)
+			tt.update(&o)
+			err := b.Validate(o)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	if _, err = b.New("O2", 100, "1", NewDate(2020, time.August, 3), OrderApplication{UID: "A1"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("New() error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestOrderAdmissionBuilder_lifecycle(t *testing.T) {
+	types := testClassifier(t, message.CLSOrderAdmissionTypes)
+	statuses := testClassifier(t, message.CLSOrderAdmissionStatuses)
+	b, err := NewOrderAdmissionBuilder(types, statuses, orderStatuses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.AddKnown("A1")
+
+	order, err := b.New("O1", 1, "15-с", NewDate(2020, time.August, 3), OrderApplication{UID: "A1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	add, err := b.Add(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<PackageData><OrderAdmission><UID>O1</UID><IdOrderAdmissionType>1</IdOrderAdmissionType>" +
+		"<IdOrderAdmissionStatus>1</IdOrderAdmissionStatus><OrderNumber>15-с</OrderNumber><OrderDate>2020-08-03</OrderDate>" +
+		"<Applications><Application><UID>A1</UID></Application></Applications></OrderAdmission></PackageData>"
+	if add.Action != message.ActionAdd || add.Datatype != message.DatatypeOrderAdmission || string(add.Data) != want {
+		t.Errorf("Add() = %v %v %s", add.Action, add.Datatype, add.Data)
+	}
+
+	if _, _, err = b.Cancel(OrderAdmission{UID: "O1"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Cancel() of the order without the status error = %v, want %v", err, ErrInvalid)
+	}
+
+	published, publish, err := b.Publish(order, NewDate(2020, time.August, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publish.Action != message.ActionEdit || !strings.Contains(string(publish.Data), "<IdOrderAdmissionStatus>2</IdOrderAdmissionStatus><OrderNumber>15-с</OrderNumber><OrderDate>2020-08-03</OrderDate><PublishDate>2020-08-04</PublishDate>") {
+		t.Errorf("Publish() = %v %s", publish.Action, publish.Data)
+	}
+	if _, err = b.Add(published); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Add() of the published order error = %v, want %v", err, ErrInvalid)
+	}
+	if _, _, err = b.Publish(published, NewDate(2020, time.August, 5)); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Publish() of the published order error = %v, want %v", err, ErrInvalid)
+	}
+
+	canceled, cancel, err := b.Cancel(published)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(cancel.Data), "<IdOrderAdmissionStatus>3</IdOrderAdmissionStatus>") {
+		t.Errorf("Cancel() = %s", cancel.Data)
+	}
+	if _, _, err = b.Cancel(canceled); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Cancel() of the canceled order error = %v, want %v", err, ErrInvalid)
+	}
+}
+
+func TestLoadOrderAdmissionBuilder(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+	b, err := LoadOrderAdmissionBuilder(ctx, ts.Client, orderStatuses, SetKnownApplications("A1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := b.New("O1", 1, "15-с", NewDate(2020, time.August, 3), OrderApplication{UID: "A1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	add, err := b.Add(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, publish, err := b.Publish(order, NewDate(2020, time.August, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, cancel, err := b.Cancel(published)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, step := range (Plan{add, publish, cancel}).Messages(ts.Crypto) {
+		if _, err = ts.Client.Send(ctx, step).Data(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if queue := ts.Queue(); len(queue) != 3 || queue[2].Action != message.ActionEdit {
+		t.Errorf("Queue() = %+v", queue)
+	}
+}
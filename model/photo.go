@@ -0,0 +1,264 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+
+	//Formats of the photos decoded by image.DecodeConfig.
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+//Formats of the photos.
+const (
+	PhotoFormatJPEG = "jpeg"
+	PhotoFormatPNG  = "png"
+)
+
+//Default constraints of the photos and the messages in bytes.
+const (
+	DefaultMaxPhotoSize   = 2 << 20
+	DefaultMaxPayloadSize = 10 << 20
+)
+
+const (
+	packageDataOverhead    = len("<PackageData></PackageData>")
+	photoFileTypePrefix    = "image/"
+	photoChecksumAlgorithm = "SHA-256"
+)
+
+//PhotoFile Photo of the entrant, datatype message.DatatypeEntrantPhotoFiles.
+type PhotoFile struct {
+	XMLName     xml.Name `xml:"EntrantPhotoFile"`
+	UID         string   `xml:"UID"`
+	GUIDEntrant string   `xml:"GUIDEntrant"`
+	FileName    string   `xml:"FileName"`
+	FileType    string   `xml:"FileType"`
+	FileSize    int      `xml:"FileSize"`
+	Width       int      `xml:"Width,omitempty"`
+	Height      int      `xml:"Height,omitempty"`
+	HashType    string   `xml:"HashType"`
+	FileHash    string   `xml:"FileHash"`
+	Base64File  string   `xml:"Base64File"`
+}
+
+//Content Receive the content of the photo checking the size and the checksum.
+func (p PhotoFile) Content() ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(p.Base64File)
+	if err != nil {
+		return nil, invalidf("photo %s: %s", p.UID, err)
+	}
+	if len(data) != p.FileSize {
+		return nil, invalidf("photo %s: size %d, want %d", p.UID, len(data), p.FileSize)
+	}
+	if sum := photoChecksum(data); !strings.EqualFold(sum, p.FileHash) {
+		return nil, invalidf("photo %s: checksum %s, want %s", p.UID, sum, p.FileHash)
+	}
+	return data, nil
+}
+
+func photoChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type photoOptions struct {
+	formats        []string
+	maxSize        int
+	minWidth       int
+	minHeight      int
+	maxPayloadSize int
+}
+
+//PhotoOption Option of PhotoBuilder.
+type PhotoOption func(*photoOptions)
+
+//SetPhotoFormats To set the formats of the photos allowed, by default PhotoFormatJPEG and PhotoFormatPNG.
+func SetPhotoFormats(formats ...string) PhotoOption {
+	return func(o *photoOptions) {
+		o.formats = formats
+	}
+}
+
+//SetMaxPhotoSize To set the maximum size of the photo in bytes, by default DefaultMaxPhotoSize.
+func SetMaxPhotoSize(size int) PhotoOption {
+	return func(o *photoOptions) {
+		o.maxSize = size
+	}
+}
+
+//SetMinPhotoDimensions To set the minimum width and height of the photo in pixels, by default not checked.
+func SetMinPhotoDimensions(width, height int) PhotoOption {
+	return func(o *photoOptions) {
+		o.minWidth = width
+		o.minHeight = height
+	}
+}
+
+//SetMaxPayloadSize To set the maximum size of the payload of the JWT of the message in bytes, by default DefaultMaxPayloadSize.
+//The payload is the PackageData encoded to base64, the header with the certificate and the signature are not counted.
+func SetMaxPayloadSize(size int) PhotoOption {
+	return func(o *photoOptions) {
+		o.maxPayloadSize = size
+	}
+}
+
+//PhotoBuilder Builder of the photos of the entrants and the messages uploading them.
+type PhotoBuilder struct {
+	options photoOptions
+}
+
+//NewPhotoBuilder Creating a new PhotoBuilder,
+//supports the following options: SetPhotoFormats, SetMaxPhotoSize, SetMinPhotoDimensions, SetMaxPayloadSize.
+func NewPhotoBuilder(opts ...PhotoOption) *PhotoBuilder {
+	options := photoOptions{
+		formats:        []string{PhotoFormatJPEG, PhotoFormatPNG},
+		maxSize:        DefaultMaxPhotoSize,
+		maxPayloadSize: DefaultMaxPayloadSize,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &PhotoBuilder{options: options}
+}
+
+//New Creating a new photo of the entrant from the content of the image file.
+func (b *PhotoBuilder) New(uid, guidEntrant, fileName string, data []byte) (PhotoFile, error) {
+	if uid == "" {
+		return PhotoFile{}, invalidf("photo: empty UID")
+	}
+	if guidEntrant == "" {
+		return PhotoFile{}, invalidf("photo %s: empty GUIDEntrant", uid)
+	}
+	if len(data) == 0 {
+		return PhotoFile{}, invalidf("photo %s: empty file", uid)
+	}
+	if b.options.maxSize > 0 && len(data) > b.options.maxSize {
+		return PhotoFile{}, invalidf("photo %s: size %d greater than %d", uid, len(data), b.options.maxSize)
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return PhotoFile{}, invalidf("photo %s: %s", uid, err)
+	}
+	if !b.allowed(format) {
+		return PhotoFile{}, invalidf("photo %s: format %s not allowed", uid, format)
+	}
+	if config.Width < b.options.minWidth || config.Height < b.options.minHeight {
+		return PhotoFile{}, invalidf("photo %s: dimensions %dx%d less than %dx%d", uid,
+			config.Width, config.Height, b.options.minWidth, b.options.minHeight)
+	}
+	return PhotoFile{
+		UID:         uid,
+		GUIDEntrant: guidEntrant,
+		FileName:    fileName,
+		FileType:    photoFileTypePrefix + format,
+		FileSize:    len(data),
+		Width:       config.Width,
+		Height:      config.Height,
+		HashType:    photoChecksumAlgorithm,
+		FileHash:    photoChecksum(data),
+		Base64File:  base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+//Open Creating a new photo of the entrant from the image file.
+func (b *PhotoBuilder) Open(uid, guidEntrant, name string) (PhotoFile, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return PhotoFile{}, fmt.Errorf("model: %w", err)
+	}
+	return b.New(uid, guidEntrant, filepath.Base(name), data)
+}
+
+func (b *PhotoBuilder) allowed(format string) bool {
+	for _, f := range b.options.formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+//Plan Receive the plan adding the photos, the photos are split to the messages with the payload of the JWT
+//not greater than SetMaxPayloadSize keeping the order.
+func (b *PhotoBuilder) Plan(photos ...PhotoFile) (Plan, error) {
+	var (
+		plan    Plan
+		records []interface{}
+		size    int
+	)
+	flush := func() error {
+		if len(records) == 0 {
+			return nil
+		}
+		data, err := packageData(records...)
+		if err != nil {
+			return err
+		}
+		plan = append(plan, Step{Action: message.ActionAdd, Datatype: message.DatatypeEntrantPhotoFiles, Data: data})
+		records, size = nil, 0
+		return nil
+	}
+	uids := map[string]bool{}
+	for _, photo := range photos {
+		if uids[photo.UID] {
+			return nil, invalidf("photo %s: duplicated", photo.UID)
+		}
+		uids[photo.UID] = true
+		if _, err := photo.Content(); err != nil {
+			return nil, err
+		}
+		data, err := xml.Marshal(photo)
+		if err != nil {
+			return nil, fmt.Errorf("model: %w", err)
+		}
+		if b.options.maxPayloadSize > 0 {
+			if payload := payloadSize(len(data)); payload > b.options.maxPayloadSize {
+				return nil, invalidf("photo %s: payload %d greater than %d", photo.UID, payload, b.options.maxPayloadSize)
+			}
+			if payloadSize(size+len(data)) > b.options.maxPayloadSize {
+				if err = flush(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		records = append(records, photo)
+		size += len(data)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+//payloadSize Receive the size of the payload of the JWT with the records of the size, see message.SignMessage.
+func payloadSize(records int) int {
+	return base64.StdEncoding.EncodedLen(packageDataOverhead + records)
+}
+
+//Messages Receive the messages adding the photos.
+func (b *PhotoBuilder) Messages(crypto sspvo.Crypto, photos ...PhotoFile) ([]*message.ActionMessage, error) {
+	plan, err := b.Plan(photos...)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Messages(crypto), nil
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestPhotoBuilder_New(t *testing.T) {
+	jpg, err := ioutil.ReadFile("testdata/photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	png, err := ioutil.ReadFile("testdata/photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		opts     []PhotoOption
+		uid      string
+		data     []byte
+		wantType string
+		wantErr  bool
+	}{
+		{name: "ok jpeg", uid: "P1", data: jpg, wantType: "image/jpeg"},
+		{name: "ok png", uid: "P1", data: png, wantType: "image/png"},
+		{name: "fail uid", data: jpg, wantErr: true},
+		{name: "fail empty", uid: "P1", wantErr: true},
+		{name: "fail format", uid: "P1", data: []byte("GIF89a"), wantErr: true},
+		{name: "fail format not allowed", uid: "P1", data: jpg, opts: []PhotoOption{SetPhotoFormats(PhotoFormatPNG)}, wantErr: true},
+		{name: "fail size", uid: "P1", data: jpg, opts: []PhotoOption{SetMaxPhotoSize(len(jpg) - 1)}, wantErr: true},
+		{name: "fail dimensions", uid: "P1", data: jpg, opts: []PhotoOption{SetMinPhotoDimensions(30, 41)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			photo, err := NewPhotoBuilder(tt.opts...).New(tt.uid, "E1", "photo", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("New() error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if photo.FileType != tt.wantType || photo.FileSize != len(tt.data) || photo.Width != 30 || photo.Height != 40 {
+				t.Errorf("New() = %+v", photo)
+			}
+			content, err := photo.Content()
+			if err != nil || !bytes.Equal(content, tt.data) {
+				t.Errorf("Content() = %v, %v", len(content), err)
+			}
+			photo.FileHash = photoChecksum([]byte("other"))
+			if _, err = photo.Content(); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Content() with bad checksum error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestPhotoBuilder_Plan(t *testing.T) {
+	var photos []PhotoFile
+	for _, uid := range []string{"P1", "P2", "P3"} {
+		photo, err := NewPhotoBuilder().Open(uid, "E1", "testdata/photo.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		photos = append(photos, photo)
+	}
+	one, err := packageData(photos[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedLen := base64.StdEncoding.EncodedLen
+
+	tests := []struct {
+		name    string
+		limit   int
+		photos  []PhotoFile
+		want    []int
+		wantErr bool
+	}{
+		{name: "one message", photos: photos, want: []int{3}},
+		{name: "two per message", limit: encodedLen(2*len(one) - packageDataOverhead), photos: photos, want: []int{2, 1}},
+		{name: "one per message", limit: encodedLen(2*len(one)-packageDataOverhead) - 1, photos: photos, want: []int{1, 1, 1}},
+		{name: "one per message encoded", limit: 2*len(one) - packageDataOverhead, photos: photos, want: []int{1, 1, 1}},
+		{name: "fail too large", limit: encodedLen(len(one)) - 1, photos: photos, wantErr: true},
+		{name: "fail duplicated", photos: []PhotoFile{photos[0], photos[0]}, wantErr: true},
+		{name: "fail checksum", photos: []PhotoFile{func() PhotoFile { p := photos[0]; p.FileHash = "00"; return p }()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []PhotoOption
+			if tt.limit != 0 {
+				opts = append(opts, SetMaxPayloadSize(tt.limit))
+			}
+			plan, err := NewPhotoBuilder(opts...).Plan(tt.photos...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Plan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(plan) != len(tt.want) {
+				t.Fatalf("Plan() = %d steps, want %d", len(plan), len(tt.want))
+			}
+			uid := 0
+			for n, step := range plan {
+				if size := encodedLen(len(step.Data)); tt.limit != 0 && size > tt.limit {
+					t.Errorf("Plan()[%d] payload = %d, want <= %d", n, size, tt.limit)
+				}
+				records, err := DecodeRecords(step.Datatype, step.Data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if step.Action != message.ActionAdd || len(records.PhotoFiles) != tt.want[n] {
+					t.Fatalf("Plan()[%d] = %s %d photos, want %d", n, step.Action, len(records.PhotoFiles), tt.want[n])
+				}
+				for _, photo := range records.PhotoFiles {
+					if photo.UID != photos[uid].UID {
+						t.Errorf("Plan()[%d] photo = %s, want %s", n, photo.UID, photos[uid].UID)
+					}
+					if _, err = photo.Content(); err != nil {
+						t.Error(err)
+					}
+					uid++
+				}
+			}
+		})
+	}
+}
+
+func TestPhotoBuilder_Exchange(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	b := NewPhotoBuilder()
+	photo, err := b.Open("P1", "E1", "testdata/photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := b.Plan(photo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Exchange(context.Background(), ts.Client, ts.Crypto, plan[0], SetPollInterval(time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"encoding/xml"
+	"sort"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//RatingApplication Application of the competitive group to be ranked.
+type RatingApplication struct {
+	UID string
+	//Scores Results of the entrance tests in the order of their priority.
+	Scores            []int
+	AchievementPoints int
+	//WithoutTests The entrant is admitted without the entrance tests, such applications are ranked first.
+	WithoutTests bool
+	//Benefit The entrant has the preferential right of the admission.
+	Benefit bool
+	Agreed  bool
+}
+
+//ScoreSum Receive the sum of the results of the entrance tests and the points of the achievements.
+func (a RatingApplication) ScoreSum() int {
+	sum := a.AchievementPoints
+	for _, score := range a.Scores {
+		sum += score
+	}
+	return sum
+}
+
+//TieBreak Rule ordering the applications with the equal sum of the points:
+//negative if a is ranked higher than b, positive if lower, 0 if the rule can not order them.
+type TieBreak func(a, b RatingApplication) int
+
+//TieBreakTestPriority Higher result of the entrance test of the higher priority is ranked higher.
+func TieBreakTestPriority(a, b RatingApplication) int {
+	for i := 0; i < len(a.Scores) && i < len(b.Scores); i++ {
+		if a.Scores[i] != b.Scores[i] {
+			return b.Scores[i] - a.Scores[i]
+		}
+	}
+	return 0
+}
+
+//TieBreakBenefit The application with the preferential right is ranked higher.
+func TieBreakBenefit(a, b RatingApplication) int {
+	return boolRank(a.Benefit, b.Benefit)
+}
+
+//TieBreakAchievements More points of the achievements are ranked higher.
+func TieBreakAchievements(a, b RatingApplication) int {
+	return b.AchievementPoints - a.AchievementPoints
+}
+
+//TieBreakAgreed The application with the consent to the admission is ranked higher.
+func TieBreakAgreed(a, b RatingApplication) int {
+	return boolRank(a.Agreed, b.Agreed)
+}
+
+func boolRank(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	}
+	return 1
+}
+
+//DefaultTieBreaks Rules ordering the applications with the equal sum of the points by default.
+var DefaultTieBreaks = []TieBreak{TieBreakTestPriority, TieBreakBenefit, TieBreakAchievements}
+
+//RatingPlace Place of the application in the rating of the competitive group.
+type RatingPlace struct {
+	XMLName             xml.Name `xml:"ApplicationRating"`
+	UIDApplication      string   `xml:"UIDApplication"`
+	UIDCompetitiveGroup string   `xml:"UIDCompetitiveGroup,omitempty"`
+	Rating              int      `xml:"Rating"`
+	WithoutTests        bool     `xml:"WithoutTests"`
+	ScoreSum            int      `xml:"ScoreSum"`
+	AchievementPoints   int      `xml:"AchievementPoints"`
+	Benefit             bool     `xml:"Benefit"`
+	Agreed              bool     `xml:"Agreed"`
+}
+
+//RatingList Ranked applications of the competitive group.
+type RatingList struct {
+	XMLName             xml.Name      `xml:"CompetitiveGroupApplicationsRating"`
+	UIDCompetitiveGroup string        `xml:"UIDCompetitiveGroup"`
+	Places              []RatingPlace `xml:"ApplicationsRating>ApplicationRating"`
+}
+
+//GroupPlaces Receive the places of the list with UIDCompetitiveGroup, see RatingBuilder.PlacesStep.
+func (l RatingList) GroupPlaces() []RatingPlace {
+	places := make([]RatingPlace, 0, len(l.Places))
+	for _, place := range l.Places {
+		place.UIDCompetitiveGroup = l.UIDCompetitiveGroup
+		places = append(places, place)
+	}
+	return places
+}
+
+//RatingDatatypes Datatypes of the rating: the places of the applications and the lists of the competitive groups.
+//The misspelled message.DatatypeCompletitiveGroupsApplicationsRating is listed by the service as the same lists,
+//it is only decoded by DecodeRecords, RatingBuilder sends the lists with message.DatatypeCompetitiveGroupsApplicationsRating.
+var RatingDatatypes = []message.Datatype{
+	message.DatatypeApplicationsRating,
+	message.DatatypeCompetitiveGroupsApplicationsRating,
+	message.DatatypeCompletitiveGroupsApplicationsRating,
+}
+
+type ratingOptions struct {
+	tieBreaks []TieBreak
+}
+
+type RatingOption func(*ratingOptions)
+
+//SetTieBreaks To set the rules ordering the applications with the equal sum of the points, by default DefaultTieBreaks.
+//The applications equal by all rules are ordered by UID.
+func SetTieBreaks(tieBreaks ...TieBreak) RatingOption {
+	return func(o *ratingOptions) {
+		o.tieBreaks = tieBreaks
+	}
+}
+
+//RatingBuilder Ranking of the applications and the payload of the rating.
+type RatingBuilder struct {
+	options ratingOptions
+}
+
+//NewRatingBuilder Creating a new RatingBuilder, supports the following options: SetTieBreaks.
+func NewRatingBuilder(opts ...RatingOption) *RatingBuilder {
+	o := ratingOptions{
+		tieBreaks: DefaultTieBreaks,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &RatingBuilder{options: o}
+}
+
+//Rank Rank the applications of the competitive group: the applications without the entrance tests first,
+//then by the sum of the points and the tie-break rules.
+func (b *RatingBuilder) Rank(uidCompetitiveGroup string, applications []RatingApplication) (RatingList, error) {
+	if uidCompetitiveGroup == "" {
+		return RatingList{}, invalidf("rating: UIDCompetitiveGroup is empty")
+	}
+	seen := map[string]bool{}
+	for _, app := range applications {
+		if app.UID == "" {
+			return RatingList{}, invalidf("rating %s: application UID is empty", uidCompetitiveGroup)
+		}
+		if seen[app.UID] {
+			return RatingList{}, invalidf("rating %s: application %s is duplicated", uidCompetitiveGroup, app.UID)
+		}
+		seen[app.UID] = true
+		for _, score := range app.Scores {
+			if score < 0 || score > 100 {
+				return RatingList{}, invalidf("rating %s: application %s: score %d is out of 0-100", uidCompetitiveGroup, app.UID, score)
+			}
+		}
+		if app.AchievementPoints < 0 {
+			return RatingList{}, invalidf("rating %s: application %s: achievement points are negative", uidCompetitiveGroup, app.UID)
+		}
+	}
+
+	ranked := append([]RatingApplication(nil), applications...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return b.compare(ranked[i], ranked[j]) < 0
+	})
+
+	list := RatingList{UIDCompetitiveGroup: uidCompetitiveGroup}
+	for i, app := range ranked {
+		list.Places = append(list.Places, RatingPlace{
+			UIDApplication:    app.UID,
+			Rating:            i + 1,
+			WithoutTests:      app.WithoutTests,
+			ScoreSum:          app.ScoreSum(),
+			AchievementPoints: app.AchievementPoints,
+			Benefit:           app.Benefit,
+			Agreed:            app.Agreed,
+		})
+	}
+	return list, nil
+}
+
+func (b *RatingBuilder) compare(a, c RatingApplication) int {
+	if rank := boolRank(a.WithoutTests, c.WithoutTests); rank != 0 {
+		return rank
+	}
+	if a.ScoreSum() != c.ScoreSum() {
+		return c.ScoreSum() - a.ScoreSum()
+	}
+	for _, tieBreak := range b.options.tieBreaks {
+		if rank := tieBreak(a, c); rank != 0 {
+			return rank
+		}
+	}
+	switch {
+	case a.UID < c.UID:
+		return -1
+	case a.UID > c.UID:
+		return 1
+	}
+	return 0
+}
+
+//Step Receive the step adding the lists of the competitive groups, datatype message.DatatypeCompetitiveGroupsApplicationsRating.
+func (b *RatingBuilder) Step(lists ...RatingList) (Step, error) {
+	if len(lists) == 0 {
+		return Step{}, invalidf("rating: lists are empty")
+	}
+	records := make([]interface{}, 0, len(lists))
+	for _, list := range lists {
+		if list.UIDCompetitiveGroup == "" {
+			return Step{}, invalidf("rating: UIDCompetitiveGroup is empty")
+		}
+		records = append(records, list)
+	}
+	return ratingStep(message.DatatypeCompetitiveGroupsApplicationsRating, records)
+}
+
+//PlacesStep Receive the step adding the places of the applications, datatype message.DatatypeApplicationsRating.
+//The places must have UIDCompetitiveGroup, see RatingList.GroupPlaces.
+func (b *RatingBuilder) PlacesStep(places ...RatingPlace) (Step, error) {
+	if len(places) == 0 {
+		return Step{}, invalidf("rating: places are empty")
+	}
+	records := make([]interface{}, 0, len(places))
+	for _, place := range places {
+		if place.UIDCompetitiveGroup == "" {
+			return Step{}, invalidf("rating: application %s: UIDCompetitiveGroup is empty", place.UIDApplication)
+		}
+		records = append(records, place)
+	}
+	return ratingStep(message.DatatypeApplicationsRating, records)
+}
+
+func ratingStep(datatype message.Datatype, records []interface{}) (Step, error) {
+	data, err := packageData(records...)
+	if err != nil {
+		return Step{}, err
+	}
+	return Step{Action: message.ActionAdd, Datatype: datatype, Data: data}, nil
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func TestRatingBuilder_Rank(t *testing.T) {
+	apps := []RatingApplication{
+		{UID: "A1", Scores: []int{80, 70}, AchievementPoints: 5},
+		{UID: "A2", Scores: []int{70, 80}, AchievementPoints: 5},
+		{UID: "A3", Scores: []int{80, 70}, AchievementPoints: 5, Benefit: true},
+		{UID: "A4", Scores: []int{90, 90}},
+		{UID: "A5", WithoutTests: true},
+		{UID: "A6", Scores: []int{80, 65}, AchievementPoints: 10, Agreed: true},
+	}
+	tests := []struct {
+		name    string
+		opts    []RatingOption
+		apps    []RatingApplication
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ok default",
+			apps: apps,
+			want: []string{"A5", "A4", "A3", "A1", "A6", "A2"},
+		},
+		{
+			name: "ok achievements first",
+			opts: []RatingOption{SetTieBreaks(TieBreakAchievements, TieBreakBenefit)},
+			apps: apps,
+			want: []string{"A5", "A4", "A6", "A3", "A1", "A2"},
+		},
+		{
+			name: "ok agreed first",
+			opts: []RatingOption{SetTieBreaks(TieBreakAgreed, TieBreakTestPriority)},
+			apps: apps,
+			want: []string{"A5", "A4", "A6", "A1", "A3", "A2"},
+		},
+		{
+			name: "ok by uid",
+			opts: []RatingOption{SetTieBreaks()},
+			apps: apps,
+			want: []string{"A5", "A4", "A1", "A2", "A3", "A6"},
+		},
+		{name: "fail duplicated", apps: []RatingApplication{{UID: "A1"}, {UID: "A1"}}, wantErr: true},
+		{name: "fail score", apps: []RatingApplication{{UID: "A1", Scores: []int{101}}}, wantErr: true},
+		{name: "fail uid", apps: []RatingApplication{{Scores: []int{80}}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := NewRatingBuilder(tt.opts...).Rank("G1", tt.apps)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rank() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Rank() error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			var got []string
+			for i, place := range list.Places {
+				if place.Rating != i+1 {
+					t.Errorf("Rank() rating of %s = %d, want %d", place.UIDApplication, place.Rating, i+1)
+				}
+				got = append(got, place.UIDApplication)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rank() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingBuilder_Step(t *testing.T) {
+	apps := []RatingApplication{{UID: "A1", Scores: []int{60}}, {UID: "A2", Scores: []int{70}, AchievementPoints: 2}}
+	place2 := "<ApplicationRating><UIDApplication>A2</UIDApplication>%s<Rating>1</Rating><WithoutTests>false</WithoutTests>" +
+		"<ScoreSum>72</ScoreSum><AchievementPoints>2</AchievementPoints><Benefit>false</Benefit><Agreed>false</Agreed></ApplicationRating>"
+	place1 := "<ApplicationRating><UIDApplication>A1</UIDApplication>%s<Rating>2</Rating><WithoutTests>false</WithoutTests>" +
+		"<ScoreSum>60</ScoreSum><AchievementPoints>0</AchievementPoints><Benefit>false</Benefit><Agreed>false</Agreed></ApplicationRating>"
+	group := "<UIDCompetitiveGroup>G1</UIDCompetitiveGroup>"
+	list := "<PackageData><CompetitiveGroupApplicationsRating><UIDCompetitiveGroup>G1</UIDCompetitiveGroup><ApplicationsRating>" +
+		fmt.Sprintf(place2, "") + fmt.Sprintf(place1, "") + "</ApplicationsRating></CompetitiveGroupApplicationsRating></PackageData>"
+
+	b := NewRatingBuilder()
+	ranked, err := b.Rank("G1", apps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		step     func() (Step, error)
+		wantType message.Datatype
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "ok lists",
+			step:     func() (Step, error) { return b.Step(ranked) },
+			wantType: message.DatatypeCompetitiveGroupsApplicationsRating,
+			want:     list,
+		},
+		{
+			name:     "ok places",
+			step:     func() (Step, error) { return b.PlacesStep(ranked.GroupPlaces()...) },
+			wantType: message.DatatypeApplicationsRating,
+			want:     "<PackageData>" + fmt.Sprintf(place2, group) + fmt.Sprintf(place1, group) + "</PackageData>",
+		},
+		{name: "fail lists empty", step: func() (Step, error) { return b.Step() }, wantErr: true},
+		{name: "fail list group", step: func() (Step, error) { return b.Step(RatingList{Places: ranked.Places}) }, wantErr: true},
+		{name: "fail places empty", step: func() (Step, error) { return b.PlacesStep() }, wantErr: true},
+		{name: "fail place group", step: func() (Step, error) { return b.PlacesStep(ranked.Places...) }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, err := tt.step()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Step() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrInvalid) {
+					t.Errorf("Step() error = %v, want %v", err, ErrInvalid)
+				}
+				return
+			}
+			if step.Action != message.ActionAdd || step.Datatype != tt.wantType || string(step.Data) != tt.want {
+				t.Errorf("Step() = %v %v %s, want %v %s", step.Action, step.Datatype, step.Data, tt.wantType, tt.want)
+			}
+
+			records, err := DecodeRecords(step.Datatype, step.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := records.Step(message.ActionAdd)
+			if err != nil || string(decoded.Data) != tt.want {
+				t.Errorf("DecodeRecords() round trip = %s, %v", decoded.Data, err)
+			}
+		})
+	}
+
+	records, err := DecodeRecords(message.DatatypeCompletitiveGroupsApplicationsRating, []byte(list))
+	if err != nil || len(records.RatingLists) != 1 || len(records.RatingLists[0].Places) != 2 {
+		t.Errorf("DecodeRecords() of the misspelled datatype = %+v, %v", records, err)
+	}
+}
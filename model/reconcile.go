@@ -0,0 +1,167 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//Reconciler Synchronization of the campaign with the service: the current state is received by message.ActionGet,
+//the difference with the desired state is sent by the steps of DiffCampaigns.
+type Reconciler struct {
+	client sspvo.Client
+	crypto sspvo.Crypto
+	opts   []ExchangeOption
+}
+
+//NewReconciler Creating a new Reconciler, supports the options of Exchange.
+func NewReconciler(client sspvo.Client, crypto sspvo.Crypto, opts ...ExchangeOption) *Reconciler {
+	return &Reconciler{client: client, crypto: crypto, opts: opts}
+}
+
+//Current Receive the state of the campaign from the service, the state has the empty Campaign and only the subdivisions
+//if the service does not find the campaign (ErrNotFound), the other rejections are returned. All records of every datatype of CampaignDatatypes are requested
+//and the records of the campaign are kept, the subdivisions are kept all.
+func (r *Reconciler) Current(ctx context.Context, uid string) (*CampaignState, error) {
+	state := &CampaignState{}
+	if err := r.get(ctx, message.DatatypeSubdivisionOrg, &state.Subdivisions); err != nil {
+		return nil, err
+	}
+
+	var campaigns []Campaign
+	err := r.get(ctx, message.DatatypeCampaign, &campaigns, Campaign{UID: uid})
+	if errors.Is(err, ErrNotFound) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(campaigns) != 1 {
+		return nil, fmt.Errorf("model: campaign %s: received %d records", uid, len(campaigns))
+	}
+	state.Campaign = campaigns[0]
+
+	var volumes []AdmissionVolume
+	if err = r.get(ctx, message.DatatypeAdmissionVolume, &volumes); err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		if volume.UIDCampaign == uid {
+			state.AdmissionVolumes = append(state.AdmissionVolumes, volume)
+		}
+	}
+
+	var groups []CompetitiveGroup
+	var programs []CompetitiveGroupProgram
+	var benefits []CompetitiveBenefit
+	var tests []EntranceTest
+	var testBenefits []EntranceTestBenefit
+	for _, get := range []struct {
+		datatype message.Datatype
+		records  interface{}
+	}{
+		{datatype: message.DatatypeCompetitiveGroups, records: &groups},
+		{datatype: message.DatatypeCompetitiveGroupPrograms, records: &programs},
+		{datatype: message.DatatypeCompetitiveBenefits, records: &benefits},
+		{datatype: message.DatatypeEntranceTests, records: &tests},
+		{datatype: message.DatatypeEntranceTestBenefits, records: &testBenefits},
+	} {
+		if err = r.get(ctx, get.datatype, get.records); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, group := range groups {
+		if group.UIDCampaign != uid {
+			continue
+		}
+		for _, program := range programs {
+			if program.UIDCompetitiveGroup == group.UID {
+				group.Programs = append(group.Programs, program)
+			}
+		}
+		for _, benefit := range benefits {
+			if benefit.UIDCompetitiveGroup == group.UID {
+				group.Benefits = append(group.Benefits, benefit)
+			}
+		}
+		for _, test := range tests {
+			if test.UIDCompetitiveGroup != group.UID {
+				continue
+			}
+			for _, benefit := range testBenefits {
+				if benefit.UIDEntranceTest == test.UID {
+					test.Benefits = append(test.Benefits, benefit)
+				}
+			}
+			group.EntranceTests = append(group.EntranceTests, test)
+		}
+		state.CompetitiveGroups = append(state.CompetitiveGroups, group)
+	}
+	return state, nil
+}
+
+//Plan Receive the steps changing the current state of the campaign to the desired one without sending them (dry run).
+func (r *Reconciler) Plan(ctx context.Context, desired *CampaignState) (Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+	current, err := r.Current(ctx, desired.Campaign.UID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffCampaigns(current, desired)
+}
+
+//Apply Send the steps of the plan in the order, stop on the first step rejected by the service.
+//The results of the applied steps are returned.
+func (r *Reconciler) Apply(ctx context.Context, plan Plan) ([]*Info, error) {
+	var results []*Info
+	for _, step := range plan {
+		info, err := Exchange(ctx, r.client, r.crypto, step, r.opts...)
+		if info != nil {
+			results = append(results, info)
+		}
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+//Reconcile Plan and apply the changes of the campaign, the plan is returned for the report.
+func (r *Reconciler) Reconcile(ctx context.Context, desired *CampaignState) (Plan, []*Info, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, err := r.Apply(ctx, plan)
+	return plan, results, err
+}
+
+//get Receive the records of the datatype into the slice records, all records if none are requested.
+func (r *Reconciler) get(ctx context.Context, datatype message.Datatype, records interface{}, requested ...interface{}) error {
+	data, err := packageData(requested...)
+	if err != nil {
+		return err
+	}
+	info, err := Exchange(ctx, r.client, r.crypto, Step{Action: message.ActionGet, Datatype: datatype, Data: data}, r.opts...)
+	if err != nil {
+		return err
+	}
+	pkg, err := info.PackageData()
+	if err != nil {
+		return err
+	}
+	return decodePackageData(pkg, records)
+}
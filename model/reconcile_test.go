@@ -0,0 +1,177 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+)
+
+func TestCampaignState_Validate(t *testing.T) {
+	campaign := Campaign{UID: "C1", Name: "Бакалавриат 2020"}
+	tests := []struct {
+		name    string
+		state   CampaignState
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			state: CampaignState{
+				Campaign:          campaign,
+				Subdivisions:      []SubdivisionOrg{{UID: "S1"}},
+				AdmissionVolumes:  []AdmissionVolume{{UID: "V1", UIDCampaign: "C1"}},
+				CompetitiveGroups: []CompetitiveGroup{{UID: "G1", Programs: []CompetitiveGroupProgram{{UID: "P1"}}}},
+			},
+		},
+		{name: "fail uid", state: CampaignState{Campaign: Campaign{Name: "Бакалавриат 2020"}}, wantErr: true},
+		{
+			name:    "fail volume campaign",
+			state:   CampaignState{Campaign: campaign, AdmissionVolumes: []AdmissionVolume{{UID: "V1", UIDCampaign: "C2"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail group campaign",
+			state:   CampaignState{Campaign: campaign, CompetitiveGroups: []CompetitiveGroup{{UID: "G1", UIDCampaign: "C2"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail group",
+			state:   CampaignState{Campaign: campaign, CompetitiveGroups: []CompetitiveGroup{{UID: "G1", Programs: []CompetitiveGroupProgram{{}}}}},
+			wantErr: true,
+		},
+		{name: "fail subdivision", state: CampaignState{Campaign: campaign, Subdivisions: []SubdivisionOrg{{}}}, wantErr: true},
+		{
+			name: "fail duplicated test",
+			state: CampaignState{Campaign: campaign, CompetitiveGroups: []CompetitiveGroup{
+				{UID: "G1", EntranceTests: []EntranceTest{{UID: "T1"}}},
+				{UID: "G2", EntranceTests: []EntranceTest{{UID: "T1"}}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.state.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	ctx := context.Background()
+	r := NewReconciler(ts.Client, ts.Crypto, SetPollInterval(time.Millisecond))
+
+	if _, err := ts.Enqueue(message.ActionAdd, message.DatatypeSubdivisionOrg,
+		[]byte("<PackageData><SubdivisionOrg><UID>S2</UID><Name>Другой</Name></SubdivisionOrg></PackageData>")); err != nil {
+		t.Fatal(err)
+	}
+
+	desired := &CampaignState{
+		Campaign: Campaign{UID: "C1", Name: "Бакалавриат 2020", YearStart: 2020, YearEnd: 2020, IDCampaignType: 1,
+			IDCampaignStatus: 1, EndDate: NewDate(2020, time.August, 31)},
+		Subdivisions:     []SubdivisionOrg{{UID: "S1", Name: "Факультет информатики"}},
+		AdmissionVolumes: []AdmissionVolume{{UID: "V1", IDDirection: 1, IDEducationLevel: 2, BudgetO: 25}},
+		CompetitiveGroups: []CompetitiveGroup{{
+			UID: "G1", UIDSubdivisionOrg: "S1", Name: "Информатика, бюджет", IDEducationLevel: 2, AdmissionNumber: 25,
+			Programs: []CompetitiveGroupProgram{{UID: "P1", Name: "Программная инженерия"}},
+			Benefits: []CompetitiveBenefit{{UID: "B1", IDBenefit: 1}},
+			EntranceTests: []EntranceTest{
+				{UID: "T1", IDSubject: 2, MinScore: 39, Benefits: []EntranceTestBenefit{{UID: "TB1", IDBenefit: 2}}},
+				{UID: "T2", IDSubject: 1, MinScore: 40},
+			},
+		}},
+	}
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Add subdivision_org S1",
+		"Add campaign C1",
+		"Add admission_volume V1",
+		"Add competitive_groups G1",
+		"Add competitive_group_programs P1",
+		"Add competitive_benefits B1",
+		"Add entrance_tests T1",
+		"Add entrance_tests T2",
+		"Add entrance_test_benefits TB1",
+	}
+	if got := planSummary(t, plan); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Plan() = %v, want %v", got, want)
+	}
+	if keys := ts.Entities(message.DatatypeCampaign); len(keys) != 0 {
+		t.Fatalf("Plan() changed the campaigns: %v", keys)
+	}
+
+	if _, results, err := r.Reconcile(ctx, desired); err != nil || len(results) != len(plan) {
+		t.Fatalf("Reconcile() = %d results, %v", len(results), err)
+	}
+
+	current, err := r.Current(ctx, "C1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current.Subdivisions) != 2 || len(current.CompetitiveGroups) != 1 || len(current.CompetitiveGroups[0].EntranceTests) != 2 ||
+		len(current.CompetitiveGroups[0].EntranceTests[0].Benefits) != 1 {
+		t.Fatalf("Current() = %+v", current)
+	}
+	if plan, err = r.Plan(ctx, desired); err != nil || len(plan) != 0 {
+		t.Fatalf("Plan() of the reconciled campaign = %v, %v", planSummary(t, plan), err)
+	}
+
+	desired.AdmissionVolumes[0].BudgetO = 30
+	desired.CompetitiveGroups[0].EntranceTests = desired.CompetitiveGroups[0].EntranceTests[1:]
+	plan, results, err := r.Reconcile(ctx, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{
+		"Remove entrance_test_benefits TB1",
+		"Remove entrance_tests T1",
+		"Edit admission_volume V1",
+	}
+	if got := planSummary(t, plan); !reflect.DeepEqual(got, want) || len(results) != 3 {
+		t.Errorf("Reconcile() = %v, %d results, want %v", got, len(results), want)
+	}
+	if keys := ts.Entities(message.DatatypeEntranceTests); !reflect.DeepEqual(keys, []string{"T2"}) {
+		t.Errorf("Entities() = %v", keys)
+	}
+
+	missing := &CampaignState{
+		Campaign:     Campaign{UID: "C2", Name: "Магистратура 2020", YearStart: 2020, YearEnd: 2020, IDCampaignType: 1, IDCampaignStatus: 1},
+		Subdivisions: []SubdivisionOrg{{UID: "S1", Name: "Факультет информатики"}},
+	}
+	if current, err = r.Current(ctx, "C2"); err != nil || current.Campaign.UID != "" || len(current.Subdivisions) != 2 {
+		t.Errorf("Current() of the missing campaign = %+v, %v", current, err)
+	}
+	if _, err = Exchange(ctx, ts.Client, ts.Crypto, Step{Action: message.ActionGet, Datatype: message.DatatypeCampaign,
+		Data: []byte("<PackageData><Campaign><UID>C2</UID></Campaign></PackageData>")}, SetPollInterval(time.Millisecond)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Exchange() of the missing campaign error = %v, want %v", err, ErrNotFound)
+	}
+	if plan, err = r.Plan(ctx, missing); err != nil || !reflect.DeepEqual(planSummary(t, plan), []string{"Add campaign C2"}) {
+		t.Errorf("Plan() of the missing campaign = %v, %v", planSummary(t, plan), err)
+	}
+
+	_, err = r.Apply(ctx, Plan{{Action: message.ActionAdd, Datatype: message.DatatypeCampaign,
+		Data: []byte("<PackageData><Campaign><UID>C1</UID></Campaign></PackageData>")}})
+	if !errors.Is(err, ErrRejected) {
+		t.Errorf("Apply() error = %v, want %v", err, ErrRejected)
+	}
+}
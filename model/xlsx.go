@@ -0,0 +1,188 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package model
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Num   int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+//readXLSX Read the cells of the first sheet of the XLSX file as the text with the numbers of the rows,
+//the empty rows are absent in the file so the numbers are read from the attribute r of the rows.
+func readXLSX(r io.ReaderAt, size int64) (rows [][]string, nums []int, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, nil, fmt.Errorf("xlsx: %w", err)
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	decode := func(name string, v interface{}) error {
+		f, ok := files[name]
+		if !ok {
+			return fmt.Errorf("xlsx: %s not found", name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("xlsx: %w", err)
+		}
+		defer rc.Close()
+		if err = xml.NewDecoder(rc).Decode(v); err != nil {
+			return fmt.Errorf("xlsx: %s: %w", name, err)
+		}
+		return nil
+	}
+
+	workbook := xlsxWorkbook{}
+	if err = decode("xl/workbook.xml", &workbook); err != nil {
+		return nil, nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, nil, fmt.Errorf("xlsx: workbook has no sheets")
+	}
+	rels := xlsxRelationships{}
+	if err = decode("xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, nil, err
+	}
+	sheetName := ""
+	for _, rel := range rels.Relationships {
+		if rel.ID == workbook.Sheets[0].RID {
+			sheetName = rel.Target
+		}
+	}
+	if sheetName == "" {
+		return nil, nil, fmt.Errorf("xlsx: sheet %s not found", workbook.Sheets[0].Name)
+	}
+	if strings.HasPrefix(sheetName, "/") {
+		sheetName = strings.TrimPrefix(sheetName, "/")
+	} else {
+		sheetName = path.Join("xl", sheetName)
+	}
+
+	shared := xlsxSharedStrings{}
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err = decode("xl/sharedStrings.xml", &shared); err != nil {
+			return nil, nil, err
+		}
+	}
+	sheet := xlsxSheet{}
+	if err = decode(sheetName, &sheet); err != nil {
+		return nil, nil, err
+	}
+
+	num := 0
+	for _, row := range sheet.Rows {
+		num++
+		if row.Num != 0 {
+			if row.Num < num {
+				return nil, nil, fmt.Errorf("xlsx: row %d: bad order", row.Num)
+			}
+			num = row.Num
+		}
+		var cells []string
+		for n, c := range row.Cells {
+			col := n
+			if c.Ref != "" {
+				if col = xlsxColumn(c.Ref); col < 0 {
+					return nil, nil, fmt.Errorf("xlsx: row %d: bad cell reference %q", num, c.Ref)
+				}
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+			switch c.Type {
+			case "s":
+				i, err := strconv.Atoi(c.Value)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, nil, fmt.Errorf("xlsx: cell %s: bad shared string %q", c.Ref, c.Value)
+				}
+				cells[col] = shared.Items[i].String()
+			case "inlineStr":
+				cells[col] = c.Inline.String()
+			default:
+				cells[col] = c.Value
+			}
+		}
+		rows = append(rows, cells)
+		nums = append(nums, num)
+	}
+	return rows, nums, nil
+}
+
+//xlsxMaxColumn Index of the last column of the sheet, XFD.
+const xlsxMaxColumn = 16383
+
+//xlsxColumn Receive the index of the column of the cell reference, A1 is 0,
+//-1 if the reference has no column or the column is past xlsxMaxColumn.
+func xlsxColumn(ref string) int {
+	col := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		if col-1 > xlsxMaxColumn {
+			return -1
+		}
+	}
+	return col - 1
+}
@@ -0,0 +1,432 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package redact
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//Field Kind of the personal data masked by the Redactor.
+type Field string
+
+const (
+	FieldSNILS      Field = "snils"
+	FieldDocSeries  Field = "doc_series"
+	FieldDocNumber  Field = "doc_number"
+	FieldBirthDate  Field = "birth_date"
+	FieldPhone      Field = "phone"
+	FieldEmail      Field = "email"
+	FieldSurname    Field = "surname"
+	FieldName       Field = "name"
+	FieldPatronymic Field = "patronymic"
+	FieldBirthplace Field = "birthplace"
+	FieldAddress    Field = "address"
+)
+
+var AllField = []Field{
+	FieldSNILS,
+	FieldDocSeries,
+	FieldDocNumber,
+	FieldBirthDate,
+	FieldPhone,
+	FieldEmail,
+	FieldSurname,
+	FieldName,
+	FieldPatronymic,
+	FieldBirthplace,
+	FieldAddress,
+}
+
+func (e Field) IsValid() bool {
+	switch e {
+	case FieldSNILS,
+		FieldDocSeries,
+		FieldDocNumber,
+		FieldBirthDate,
+		FieldPhone,
+		FieldEmail,
+		FieldSurname,
+		FieldName,
+		FieldPatronymic,
+		FieldBirthplace,
+		FieldAddress:
+		return true
+	}
+	return false
+}
+
+func (e Field) String() string {
+	return string(e)
+}
+
+//DefaultElements Names of the XML elements and JSON keys holding the field in the PackageData of the service.
+//The name "Parent/Name" matches the element only inside the parent, e.g. Name is also the name of the classifier items.
+var DefaultElements = map[Field][]string{
+	FieldSNILS:      {"Snils"},
+	FieldDocSeries:  {"DocSeries"},
+	FieldDocNumber:  {"DocNumber"},
+	FieldBirthDate:  {"Birthday", "BirthDate"},
+	FieldPhone:      {"Phone"},
+	FieldEmail:      {"Email"},
+	FieldSurname:    {"Surname"},
+	FieldName:       {"Entrant/Name", "Identification/Name"},
+	FieldPatronymic: {"Patronymic"},
+	FieldBirthplace: {"Birthplace"},
+	FieldAddress:    {"Address"},
+}
+
+type options struct {
+	fields   []Field
+	elements map[Field][]string
+	mask     func(field Field, value string) string
+}
+
+type Option func(*options)
+
+//SetFields To set the fields option with the masked fields, by default AllField.
+func SetFields(fields ...Field) Option {
+	return func(o *options) {
+		o.fields = fields
+	}
+}
+
+//SetElements To set the names of the XML elements and JSON keys of the field in addition to DefaultElements,
+//the name "Parent/Name" matches the element only inside the parent.
+func SetElements(field Field, names ...string) Option {
+	return func(o *options) {
+		if o.elements == nil {
+			o.elements = map[Field][]string{}
+		}
+		o.elements[field] = append(o.elements[field], names...)
+	}
+}
+
+//SetMask To set the mask option that replaces the value of the field, by default Mask.
+func SetMask(mask func(field Field, value string) string) Option {
+	return func(o *options) {
+		o.mask = mask
+	}
+}
+
+//Mask Replace the letters and digits of the value with '*' keeping the separators, so the format stays recognizable.
+func Mask(_ Field, value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return '*'
+		}
+		return r
+	}, value)
+}
+
+//Redactor Engine that masks the personal data in the payloads of the service before they are logged or stored.
+type Redactor struct {
+	mask  func(field Field, value string) string
+	names map[string]Field
+	//localNames Fields by the name without the parent, used when the parent is unknown.
+	localNames map[string]Field
+	//rawElements Regular expression used on the XML that can not be parsed.
+	rawElements *regexp.Regexp
+}
+
+//NewRedactor Creating a new Redactor, supports the following options: SetFields, SetElements, SetMask.
+func NewRedactor(opts ...Option) *Redactor {
+	o := options{
+		fields: AllField,
+		mask:   Mask,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r := &Redactor{
+		mask:       o.mask,
+		names:      map[string]Field{},
+		localNames: map[string]Field{},
+	}
+	var quoted []string
+	for _, field := range o.fields {
+		for _, name := range append(append([]string(nil), DefaultElements[field]...), o.elements[field]...) {
+			r.names[strings.ToLower(name)] = field
+			local := name[strings.LastIndex(name, "/")+1:]
+			if _, ok := r.localNames[strings.ToLower(local)]; !ok {
+				r.localNames[strings.ToLower(local)] = field
+				quoted = append(quoted, regexp.QuoteMeta(local))
+			}
+		}
+	}
+	sort.Strings(quoted)
+	if len(quoted) > 0 {
+		r.rawElements = regexp.MustCompile(`(?i)(<(?:[\w.-]+:)?(?:` + strings.Join(quoted, "|") + `)(?:\s[^>]*)?>)([^<]*)(<)`)
+	}
+	return r
+}
+
+var defaultRedactor = NewRedactor()
+
+//Redact Mask the personal data with the default Redactor, see Redactor.Redact.
+func Redact(data []byte) []byte {
+	return defaultRedactor.Redact(data)
+}
+
+//Redact Mask the personal data of the payload: PackageData XML, JSON bodies of the requests and responses,
+//tokens and ResponseToken whose payload is decoded, masked and encoded back. The signature of such tokens is no longer valid.
+//Unknown payloads are returned unchanged.
+func (r *Redactor) Redact(data []byte) []byte {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return data
+	case trimmed[0] == '<':
+		return r.redactXML(data)
+	case trimmed[0] == '{' || trimmed[0] == '[':
+		return r.redactJSON(data)
+	}
+	if token, ok := r.redactToken(string(trimmed)); ok {
+		return []byte(token)
+	}
+	return data
+}
+
+//field Receive the field of the element inside the parent.
+func (r *Redactor) field(parent, name string) (Field, bool) {
+	name = strings.ToLower(name)
+	if field, ok := r.names[strings.ToLower(parent)+"/"+name]; ok {
+		return field, true
+	}
+	field, ok := r.names[name]
+	return field, ok
+}
+
+type span struct {
+	start, end int
+	field      Field
+	value      string
+}
+
+//jsonSpan Replacement of the JSON value at the offsets.
+type jsonSpan struct {
+	start, end int
+	value      []byte
+}
+
+//jsonFrame Object or array of the JSON being redacted.
+type jsonFrame struct {
+	object    bool
+	expectKey bool
+	//parent Key of the object holding the frame, arrays pass it to their items.
+	parent string
+	key    string
+}
+
+func (r *Redactor) redactXML(data []byte) []byte {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	var (
+		spans []span
+		stack []string
+	)
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return r.redactRawXML(data)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if len(stack) == 0 {
+				continue
+			}
+			parent := ""
+			if len(stack) > 1 {
+				parent = stack[len(stack)-2]
+			}
+			if field, ok := r.field(parent, stack[len(stack)-1]); ok && len(bytes.TrimSpace(t)) > 0 {
+				spans = append(spans, span{start: int(start), end: int(decoder.InputOffset()), field: field, value: string(t)})
+			}
+		}
+	}
+
+	if len(spans) == 0 {
+		return data
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)))
+	last := 0
+	for _, s := range spans {
+		buf.Write(data[last:s.start])
+		_ = xml.EscapeText(buf, []byte(r.mask(s.field, s.value)))
+		last = s.end
+	}
+	buf.Write(data[last:])
+	return buf.Bytes()
+}
+
+//redactRawXML Mask the elements of the XML that can not be parsed, so the broken payload does not leak the personal data.
+func (r *Redactor) redactRawXML(data []byte) []byte {
+	if r.rawElements == nil {
+		return data
+	}
+	return r.rawElements.ReplaceAllFunc(data, func(match []byte) []byte {
+		sub := r.rawElements.FindSubmatch(match)
+		name := strings.TrimLeft(string(sub[1]), "<")
+		if i := strings.IndexAny(name, " \t\r\n>"); i >= 0 {
+			name = name[:i]
+		}
+		if i := strings.Index(name, ":"); i >= 0 {
+			name = name[i+1:]
+		}
+		field := r.localNames[strings.ToLower(name)]
+		return append(append(append([]byte(nil), sub[1]...), r.mask(field, string(sub[2]))...), sub[3]...)
+	})
+}
+
+//redactJSON Mask the values of the JSON in place, the order of the keys and the formatting are kept.
+//The values before the broken part of the JSON are masked, so it does not leak the personal data.
+func (r *Redactor) redactJSON(data []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var (
+		spans []jsonSpan
+		stack []*jsonFrame
+		last  int
+	)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			//the values read before the broken or truncated part are still masked
+			break
+		}
+		start := int(offset) + len(data[offset:]) - len(bytes.TrimLeft(data[offset:], " \t\r\n:,"))
+		last = int(decoder.InputOffset())
+
+		var frame *jsonFrame
+		if len(stack) > 0 {
+			frame = stack[len(stack)-1]
+		}
+		parent, key := "", ""
+		if frame != nil {
+			parent, key = frame.parent, frame.key
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			switch t {
+			case '{':
+				stack = append(stack, &jsonFrame{object: true, expectKey: true, parent: key})
+			case '[':
+				stack = append(stack, &jsonFrame{parent: parent, key: key})
+			default:
+				stack = stack[:len(stack)-1]
+				jsonValueDone(stack)
+			}
+		case string:
+			if frame != nil && frame.object && frame.expectKey {
+				frame.key, frame.expectKey = t, false
+				continue
+			}
+			if value, ok := r.redactString(parent, key, t); ok {
+				spans = append(spans, jsonSpan{start: start, end: last, value: jsonString(value)})
+			}
+			jsonValueDone(stack)
+		case json.Number:
+			if field, ok := r.field(parent, key); ok {
+				spans = append(spans, jsonSpan{start: start, end: last, value: jsonString(r.mask(field, t.String()))})
+			}
+			jsonValueDone(stack)
+		default:
+			jsonValueDone(stack)
+		}
+		if len(stack) == 0 {
+			break
+		}
+	}
+
+	if len(spans) == 0 {
+		return data
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)))
+	last = 0
+	for _, s := range spans {
+		buf.Write(data[last:s.start])
+		buf.Write(s.value)
+		last = s.end
+	}
+	buf.Write(data[last:])
+	return buf.Bytes()
+}
+
+//jsonValueDone Mark the value of the current object read, the next string is the key.
+func jsonValueDone(stack []*jsonFrame) {
+	if len(stack) > 0 && stack[len(stack)-1].object {
+		stack[len(stack)-1].expectKey = true
+	}
+}
+
+//redactString Mask the string value of the key: the field, the embedded XML or the token.
+func (r *Redactor) redactString(parent, key, value string) (string, bool) {
+	if field, ok := r.field(parent, key); ok {
+		return r.mask(field, value), true
+	}
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "<") {
+		res := string(r.redactXML([]byte(value)))
+		return res, res != value
+	}
+	if token, ok := r.redactToken(trimmed); ok {
+		return token, token != trimmed
+	}
+	return "", false
+}
+
+//jsonString Encode the string without escaping the HTML characters.
+func jsonString(value string) []byte {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+//redactToken Mask the header and the payload of the token "header.payload.sign" encoded in base64.
+func (r *Redactor) redactToken(token string) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", false
+	}
+	var decoded [2][]byte
+	for i := range decoded {
+		data, err := base64.StdEncoding.DecodeString(parts[i])
+		if err != nil {
+			return "", false
+		}
+		decoded[i] = data
+	}
+	for i, data := range decoded {
+		parts[i] = base64.StdEncoding.EncodeToString(r.Redact(data))
+	}
+	return strings.Join(parts, "."), true
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package redact
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+const entrant = `<?xml version="1.0" encoding="utf-8"?>
+<PackageData>
+	<Entrant>
+		<GUID>E1</GUID>
+		<Snils>123-456-789 01</Snils>
+		<Birthday>2002-05-17</Birthday>
+		<Phone>+7 (900) 000-00-00</Phone>
+		<Email>ivanov@example.com</Email>
+		<Identification>
+			<DocSeries>4510</DocSeries>
+			<DocNumber>123456</DocNumber>
+		</Identification>
+	</Entrant>
+</PackageData>`
+
+const entrantMasked = `<?xml version="1.0" encoding="utf-8"?>
+<PackageData>
+	<Entrant>
+		<GUID>E1</GUID>
+		<Snils>***-***-*** **</Snils>
+		<Birthday>****-**-**</Birthday>
+		<Phone>+* (***) ***-**-**</Phone>
+		<Email>******@*******.***</Email>
+		<Identification>
+			<DocSeries>****</DocSeries>
+			<DocNumber>******</DocNumber>
+		</Identification>
+	</Entrant>
+</PackageData>`
+
+func token(header, payload string) string {
+	return base64.StdEncoding.EncodeToString([]byte(header)) + "." +
+		base64.StdEncoding.EncodeToString([]byte(payload)) + ".c2lnbg=="
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	header := `{"OGRN":"test","action":"Add","data_type":"entrants"}`
+
+	tests := []struct {
+		name string
+		opts []Option
+		data string
+		want string
+	}{
+		{
+			name: "ok xml",
+			data: entrant,
+			want: entrantMasked,
+		},
+		{
+			name: "ok xml namespace and attributes",
+			data: `<ns:PackageData xmlns:ns="urn:x"><ns:Snils type="a">12345678901</ns:Snils></ns:PackageData>`,
+			want: `<ns:PackageData xmlns:ns="urn:x"><ns:Snils type="a">***********</ns:Snils></ns:PackageData>`,
+		},
+		{
+			name: "ok broken xml",
+			data: `<PackageData><Snils>12345678901</Snils><Phone>89000000000</Phone><Name>`,
+			want: `<PackageData><Snils>***********</Snils><Phone>***********</Phone><Name>`,
+		},
+		{
+			name: "ok selected fields",
+			opts: []Option{SetFields(FieldSNILS)},
+			data: `<PackageData><Snils>12345678901</Snils><Phone>89000000000</Phone></PackageData>`,
+			want: `<PackageData><Snils>***********</Snils><Phone>89000000000</Phone></PackageData>`,
+		},
+		{
+			name: "ok own elements and mask",
+			opts: []Option{
+				SetElements(FieldDocNumber, "Number"),
+				SetMask(func(field Field, value string) string { return "[" + field.String() + "]" }),
+			},
+			data: `<PackageData><Number>123</Number></PackageData>`,
+			want: `<PackageData><Number>[doc_number]</Number></PackageData>`,
+		},
+		{
+			name: "ok json",
+			data: `{"Snils":"12345678901","IDJWT":"1","Phone":89000000000}`,
+			want: `{"Snils":"***********","IDJWT":"1","Phone":"***********"}`,
+		},
+		{
+			name: "ok json in place",
+			data: "{\n  \"Note\": \"<b>&</b>\",\n  \"Entrant\": {\"Name\": \"Иван\", \"Emails\": [{\"Email\": \"a@b.c\"}]},\n  \"Name\": \"ВУЗ\"\n}",
+			want: "{\n  \"Note\": \"<b>&</b>\",\n  \"Entrant\": {\"Name\": \"****\", \"Emails\": [{\"Email\": \"*@*.*\"}]},\n  \"Name\": \"ВУЗ\"\n}",
+		},
+		{
+			name: "ok json array of fields",
+			data: `{"Phone":["8900","8901"],"Items":[1,true,null]}`,
+			want: `{"Phone":["****","****"],"Items":[1,true,null]}`,
+		},
+		{
+			name: "ok truncated json",
+			data: `{"Snils":"1234"`,
+			want: `{"Snils":"****"`,
+		},
+		{
+			name: "ok broken json",
+			data: `{"Snils":"1234",}`,
+			want: `{"Snils":"****",}`,
+		},
+		{
+			name: "ok xml names",
+			data: `<PackageData><Entrant><Surname>Иванов</Surname><Name>Иван</Name><Patronymic>Иванович</Patronymic>` +
+				`<Birthplace>г. Москва</Birthplace></Entrant><Subject><Name>Математика</Name></Subject></PackageData>`,
+			want: `<PackageData><Entrant><Surname>******</Surname><Name>****</Name><Patronymic>********</Patronymic>` +
+				`<Birthplace>*. ******</Birthplace></Entrant><Subject><Name>Математика</Name></Subject></PackageData>`,
+		},
+		{
+			name: "ok response token",
+			data: `{"ResponseToken":"` + token(header, `<PackageData><Snils>12345678901</Snils></PackageData>`) + `"}`,
+			want: `{"ResponseToken":"` + token(header, `<PackageData><Snils>***********</Snils></PackageData>`) + `"}`,
+		},
+		{
+			name: "ok token",
+			data: token(header, `<PackageData><Email>a@b.c</Email></PackageData>`),
+			want: token(header, `<PackageData><Email>*@*.*</Email></PackageData>`),
+		},
+		{
+			name: "ok unknown",
+			data: "plain text",
+			want: "plain text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(NewRedactor(tt.opts...).Redact([]byte(tt.data))); got != tt.want {
+				t.Errorf("Redact() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactor_Redact_token(t *testing.T) {
+	request := NewRedactor().Redact([]byte(`{"token":"` + token(`{"action":"Add"}`, entrant) + `"}`))
+	if !strings.Contains(string(request), base64.StdEncoding.EncodeToString([]byte(entrantMasked))) {
+		t.Errorf("Redact() = %s", request)
+	}
+	if got := string(Redact([]byte(entrant))); got != entrantMasked {
+		t.Errorf("Redact() = %s", got)
+	}
+}
@@ -8,17 +8,39 @@
 package response
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/tracing"
 )
 
 type Response struct {
-	resp *sspvo.ClientResponse
-	err  error
+	resp   *sspvo.ClientResponse
+	err    error
+	logger sspvo.Logger
+	ctx    context.Context
+}
+
+//SetContext To set the context of the sending, the verification span is its child, see sspvo.ContextResponse.
+func (r *Response) SetContext(ctx context.Context) {
+	r.ctx = ctx
+}
+
+func (r *Response) context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+//SetLogger To set the logger receiving the events of the response, see sspvo.Loggable.
+func (r *Response) SetLogger(logger sspvo.Logger) {
+	r.logger = logger
 }
 
 func (r *Response) SetClientResponse(resp *sspvo.ClientResponse) {
@@ -56,6 +78,9 @@ type SignResponse struct {
 	Response
 	skipVerify bool
 	crypto     sspvo.Crypto
+
+	verifyOnce sync.Once
+	verifyErr  error
 }
 
 func (r *SignResponse) Data() ([]byte, error) {
@@ -63,27 +88,108 @@ func (r *SignResponse) Data() ([]byte, error) {
 	if err != nil {
 		return data, err
 	}
+	if err = r.Verify(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+//Verify Check the signature of the ResponseToken once, the span and the event of the verification are written
+//on the first call and the result is kept for the next ones, see sspvo.VerifiableResponse.
+func (r *SignResponse) Verify() error {
+	r.verifyOnce.Do(func() {
+		r.verifyErr = r.verify()
+	})
+	return r.verifyErr
+}
+
+func (r *SignResponse) verify() error {
+	data, err := r.Response.Data()
+	if err != nil {
+		return err
+	}
 	signStruct := struct {
 		ResponseToken string `json:"ResponseToken"`
 	}{}
 
 	err = json.Unmarshal(data, &signStruct)
 	if err != nil {
-		return nil, fmt.Errorf("SignResponse: %w", err)
+		return fmt.Errorf("SignResponse: %w", err)
 	}
 
 	if signStruct.ResponseToken == "" {
-		return data, nil
+		return nil
 	}
 
+	header := r.responseHeader(signStruct.ResponseToken)
+	_, span := tracing.Start(r.context(), tracing.SpanVerify, tracing.FieldsAttributes(header)...)
+
 	ok, err := r.verifyResponseToken(signStruct.ResponseToken)
+	r.logVerified(header, ok, err)
+	span.SetAttributes(tracing.AttrVerified.Bool(ok))
 	if err != nil {
-		return nil, fmt.Errorf("SignResponse: %w", err)
+		err = fmt.Errorf("SignResponse: %w", err)
+	} else if !ok {
+		err = fmt.Errorf("SignResponse: %w", sspvo.ErrBadSign)
 	}
-	if !ok {
-		return nil, fmt.Errorf("SignResponse: %w", sspvo.ErrBadSign)
+	tracing.End(span, err)
+	return err
+}
+
+//responseHeader Receive the IDJWT, action and datatype from the header of the ResponseToken.
+func (r *SignResponse) responseHeader(responseToken string) sspvo.JWTFields {
+	header := struct {
+		IDJWT    interface{} `json:"IDJWT"`
+		Action   string      `json:"action"`
+		DataType string      `json:"data_type"`
+	}{}
+	if data, err := base64.StdEncoding.DecodeString(r.parseResponseToken(responseToken).Header); err == nil {
+		_ = json.Unmarshal(data, &header)
 	}
-	return data, nil
+
+	fields := sspvo.JWTFields{}
+	if header.IDJWT != nil {
+		fields[sspvo.FieldIdJWT] = header.IDJWT
+	}
+	if header.Action != "" {
+		fields[sspvo.FieldAction] = header.Action
+	}
+	if header.DataType != "" {
+		fields[sspvo.FieldDataType] = header.DataType
+	}
+	return fields
+}
+
+var headerLogKeys = []struct {
+	field sspvo.FieldName
+	key   string
+}{
+	{field: sspvo.FieldIdJWT, key: sspvo.LogKeyIDJWT},
+	{field: sspvo.FieldAction, key: sspvo.LogKeyAction},
+	{field: sspvo.FieldDataType, key: sspvo.LogKeyDatatype},
+}
+
+//logVerified Write the event about the verification of the ResponseToken with the fields of its header.
+func (r *SignResponse) logVerified(header sspvo.JWTFields, ok bool, err error) {
+	if r.logger == nil {
+		return
+	}
+
+	fields := []sspvo.LogField{{Key: sspvo.LogKeyVerified, Value: ok}}
+	for _, k := range headerLogKeys {
+		if v, exists := header[k.field]; exists {
+			fields = append(fields, sspvo.LogField{Key: k.key, Value: v})
+		}
+	}
+
+	level := sspvo.LogInfo
+	if err != nil {
+		fields = append(fields, sspvo.LogField{Key: sspvo.LogKeyError, Value: err.Error()})
+		level = sspvo.LogError
+	} else if !ok {
+		level = sspvo.LogWarn
+	}
+	r.logger.Log(r.context(), level, sspvo.EventResponseVerified, fields...)
 }
 
 func (r *SignResponse) parseResponseToken(responseToken string) (res *sspvo.Token) {
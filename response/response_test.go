@@ -8,6 +8,8 @@
 package response
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"reflect"
@@ -473,3 +475,82 @@ func TestSignResponse_verifyResponseToken(t *testing.T) {
 		})
 	}
 }
+
+type testLogger struct {
+	level  sspvo.LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+func (l *testLogger) Log(_ context.Context, level sspvo.LogLevel, msg string, fields ...sspvo.LogField) {
+	l.level, l.msg, l.fields = level, msg, map[string]interface{}{}
+	for _, f := range fields {
+		l.fields[f.Key] = f.Value
+	}
+}
+
+func TestSignResponse_logVerified(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		ok        bool
+		wantLevel sspvo.LogLevel
+	}{
+		{name: "ok", ok: true, wantLevel: sspvo.LogInfo},
+		{name: "bad sign", ok: false, wantLevel: sspvo.LogWarn},
+		{name: "fail", err: errors.New("fail"), wantLevel: sspvo.LogError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &testLogger{}
+			r := NewSignResponse(nil, true)
+			r.SetLogger(logger)
+			r.logVerified(r.responseHeader(strings.TrimSpace(validResponseToken)), tt.ok, tt.err)
+			if logger.msg != sspvo.EventResponseVerified || logger.level != tt.wantLevel {
+				t.Errorf("logVerified() = %v %s, want %v", logger.level, logger.msg, tt.wantLevel)
+			}
+			if logger.fields[sspvo.LogKeyVerified] != tt.ok || logger.fields[sspvo.LogKeyDatatype] != "Error" ||
+				logger.fields[sspvo.LogKeyIDJWT] != float64(1386171) {
+				t.Errorf("logVerified() fields = %v", logger.fields)
+			}
+			if (logger.fields[sspvo.LogKeyError] != nil) != (tt.err != nil) {
+				t.Errorf("logVerified() error field = %v", logger.fields[sspvo.LogKeyError])
+			}
+		})
+	}
+
+	r := NewSignResponse(nil, true)
+	r.logVerified(r.responseHeader(validResponseToken), true, nil)
+}
+
+type countLogger struct {
+	count int
+}
+
+func (l *countLogger) Log(context.Context, sspvo.LogLevel, string, ...sspvo.LogField) {
+	l.count++
+}
+
+func TestSignResponse_Verify(t *testing.T) {
+	body, err := json.Marshal(map[string]string{"ResponseToken": strings.TrimSpace(badResponseToken)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := &countLogger{}
+	r := NewSignResponse(getCrypto(t, validCert), false)
+	r.SetLogger(logger)
+	r.SetClientResponse(&sspvo.ClientResponse{Code: 200, Body: body})
+
+	verifyErr := r.Verify()
+	if verifyErr == nil {
+		t.Fatal("Verify() of the bad token must fail")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err = r.Data(); err != verifyErr {
+			t.Fatalf("Data() error = %v, want %v", err, verifyErr)
+		}
+	}
+	if logger.count != 1 {
+		t.Errorf("verified %d times, want once", logger.count)
+	}
+}
@@ -29,11 +29,32 @@ type Crypto interface {
 	Verify(sign, digest []byte) (ok bool, err error)
 }
 
+//CryptoSnapshot Implemented by crypto that may swap its keys at runtime, the snapshot is used to sign one message consistently.
+type CryptoSnapshot interface {
+	Snapshot() Crypto
+}
+
 type Client interface {
 	Send(ctx context.Context, msg Message) (res Response)
 	PrepareBody(msg Message) ([]byte, error)
 }
 
+//ContextMessage Implemented by the messages that sign the token within the context of the sending, used for tracing.
+type ContextMessage interface {
+	GetJWTContext(ctx context.Context) ([]byte, error)
+}
+
+//ContextResponse Implemented by the responses that keep the context of the sending, used for tracing the verification.
+type ContextResponse interface {
+	SetContext(ctx context.Context)
+}
+
+//VerifiableResponse Implemented by the responses that verify the signature of the data,
+//the client verifies them within the span of the sending and the result is kept for Data.
+type VerifiableResponse interface {
+	Verify() error
+}
+
 type Token struct {
 	Header  string
 	Payload string
@@ -0,0 +1,351 @@
+//Code generated by go run gen_cls_fixtures.go; DO NOT EDIT.
+
+package test_server_epgu
+
+import "github.com/ftomza/go-sspvo/message"
+
+var clsFixtures = map[message.CLS]string{
+	"AchievementCategory": `<?xml version="1.0" encoding="UTF-8"?>
+<AchievementCategory>
+<Category><ID>1</ID><Name>Статус чемпиона и призера Олимпийских игр, Паралимпийских игр и Сурдлимпийских игр</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Наличие золотого знака отличия ГТО</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Наличие аттестата о среднем общем образовании с отличием</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>4</ID><Name>Наличие диплома о среднем профессиональном образовании с отличием</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>5</ID><Name>Волонтерская (добровольческая) деятельность</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>6</ID><Name>Участие и (или) результаты участия в олимпиадах и иных интеллектуальных и (или) творческих конкурсах</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>7</ID><Name>Итоговое сочинение</Name><IDCampaignType>1</IDCampaignType><Actual>true</Actual></Category>
+<Category><ID>8</ID><Name>Научные публикации</Name><IDCampaignType>2</IDCampaignType><Actual>true</Actual></Category>
+</AchievementCategory>
+`,
+	"AppealStatuses": `<?xml version="1.0" encoding="UTF-8"?>
+<AppealStatuses>
+<Status><ID>1</ID><Name>Новая</Name><Actual>true</Actual></Status>
+<Status><ID>2</ID><Name>На рассмотрении</Name><Actual>true</Actual></Status>
+<Status><ID>3</ID><Name>Удовлетворена</Name><Actual>true</Actual></Status>
+<Status><ID>4</ID><Name>Отклонена</Name><Actual>true</Actual></Status>
+</AppealStatuses>
+`,
+	"ApplicationStatuses": `<?xml version="1.0" encoding="UTF-8"?>
+<ApplicationStatuses>
+<Status><ID>1</ID><Code>new</Code><Name>Новое</Name><Actual>true</Actual></Status>
+<Status><ID>2</ID><Code>in_work</Code><Name>Принято в обработку</Name><Actual>true</Actual></Status>
+<Status><ID>3</ID><Code>need_edit</Code><Name>Требуются исправления</Name><Actual>true</Actual></Status>
+<Status><ID>4</ID><Code>competition</Code><Name>Участвует в конкурсе</Name><Actual>true</Actual></Status>
+<Status><ID>5</ID><Code>enrolled</Code><Name>Зачислен</Name><Actual>true</Actual></Status>
+<Status><ID>6</ID><Code>denied</Code><Name>Отказано в участии в конкурсе</Name><Actual>true</Actual></Status>
+<Status><ID>7</ID><Code>revoked</Code><Name>Отозвано поступающим</Name><Actual>true</Actual></Status>
+<Status><ID>8</ID><Code>excluded</Code><Name>Исключен из приказа о зачислении</Name><Actual>true</Actual></Status>
+</ApplicationStatuses>
+`,
+	"Benefit": `<?xml version="1.0" encoding="UTF-8"?>
+<Benefit>
+<Benefit><ID>1</ID><ShortName>БВИ</ShortName><Name>Без вступительных испытаний</Name><Actual>true</Actual></Benefit>
+<Benefit><ID>2</ID><ShortName>100 баллов</ShortName><Name>Приравнивание к лицам, набравшим максимальное количество баллов ЕГЭ</Name><Actual>true</Actual></Benefit>
+<Benefit><ID>3</ID><ShortName>Особая квота</ShortName><Name>Прием на обучение в пределах особой квоты</Name><Actual>true</Actual></Benefit>
+<Benefit><ID>4</ID><ShortName>Преимущественное право</ShortName><Name>Преимущественное право зачисления</Name><Actual>true</Actual></Benefit>
+</Benefit>
+`,
+	"CampaignStatus": `<?xml version="1.0" encoding="UTF-8"?>
+<CampaignStatus>
+<Status><ID>1</ID><Name>Набор не начался</Name><Actual>true</Actual></Status>
+<Status><ID>2</ID><Name>Идет набор</Name><Actual>true</Actual></Status>
+<Status><ID>3</ID><Name>Завершена</Name><Actual>true</Actual></Status>
+</CampaignStatus>
+`,
+	"CampaignType": `<?xml version="1.0" encoding="UTF-8"?>
+<CampaignType>
+<Type><ID>1</ID><Name>Прием на обучение на бакалавриат/специалитет</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Name>Прием на обучение в магистратуру</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><Name>Прием на подготовку кадров высшей квалификации</Name><Actual>true</Actual></Type>
+<Type><ID>4</ID><Name>Прием на обучение в СПО</Name><Actual>true</Actual></Type>
+<Type><ID>5</ID><Name>Прием иностранцев по направлениям Минобрнауки</Name><Actual>true</Actual></Type>
+</CampaignType>
+`,
+	"CompatriotCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<CompatriotCategories>
+<Category><ID>1</ID><Name>Граждане СССР, проживающие в государствах, входивших в состав СССР</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Лица, проживающие за рубежом и относящиеся к народам, исторически проживающим на территории РФ</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Потомки соотечественников</Name><Actual>true</Actual></Category>
+</CompatriotCategories>
+`,
+	"CompositionThemes": `<?xml version="1.0" encoding="UTF-8"?>
+<CompositionThemes>
+<Theme><ID>1</ID><Name>Забыть нельзя помнить</Name><Actual>true</Actual></Theme>
+<Theme><ID>2</ID><Name>Я и другие</Name><Actual>true</Actual></Theme>
+<Theme><ID>3</ID><Name>Время перемен</Name><Actual>true</Actual></Theme>
+<Theme><ID>4</ID><Name>Разговор с собой</Name><Actual>true</Actual></Theme>
+<Theme><ID>5</ID><Name>Между прошлым и будущим</Name><Actual>true</Actual></Theme>
+</CompositionThemes>
+`,
+	"Directions": `<?xml version="1.0" encoding="UTF-8"?>
+<Directions>
+<Direction><ID>1</ID><Code>01.03.02</Code><Name>Прикладная математика и информатика</Name><IDEducationLevel>2</IDEducationLevel><ParentID></ParentID><Actual>true</Actual></Direction>
+<Direction><ID>2</ID><Code>09.03.01</Code><Name>Информатика и вычислительная техника</Name><IDEducationLevel>2</IDEducationLevel><ParentID></ParentID><Actual>true</Actual></Direction>
+<Direction><ID>3</ID><Code>09.03.04</Code><Name>Программная инженерия</Name><IDEducationLevel>2</IDEducationLevel><ParentID></ParentID><Actual>true</Actual></Direction>
+<Direction><ID>4</ID><Code>38.03.01</Code><Name>Экономика</Name><IDEducationLevel>2</IDEducationLevel><ParentID></ParentID><Actual>true</Actual></Direction>
+<Direction><ID>5</ID><Code>31.05.01</Code><Name>Лечебное дело</Name><IDEducationLevel>3</IDEducationLevel><ParentID></ParentID><Actual>true</Actual></Direction>
+<Direction><ID>6</ID><Code>09.04.01</Code><Name>Информатика и вычислительная техника</Name><IDEducationLevel>4</IDEducationLevel><ParentID></ParentID><Actual>true</Actual></Direction>
+</Directions>
+`,
+	"DisabilityTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<DisabilityTypes>
+<Type><ID>1</ID><Name>Инвалид I группы</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Name>Инвалид II группы</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><Name>Инвалид III группы</Name><Actual>true</Actual></Type>
+<Type><ID>4</ID><Name>Ребенок-инвалид</Name><Actual>true</Actual></Type>
+<Type><ID>5</ID><Name>Инвалид с детства</Name><Actual>true</Actual></Type>
+</DisabilityTypes>
+`,
+	"DocumentCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<DocumentCategories>
+<Category><ID>1</ID><Name>Документ, удостоверяющий личность</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Документ об образовании</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Документ, подтверждающий особое право</Name><Actual>true</Actual></Category>
+<Category><ID>4</ID><Name>Документ, подтверждающий индивидуальное достижение</Name><Actual>true</Actual></Category>
+<Category><ID>5</ID><Name>Прочие документы</Name><Actual>true</Actual></Category>
+</DocumentCategories>
+`,
+	"DocumentTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<DocumentTypes>
+<Type><ID>1</ID><IDCategory>1</IDCategory><Name>Паспорт гражданина РФ</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><IDCategory>1</IDCategory><Name>Заграничный паспорт гражданина РФ</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><IDCategory>1</IDCategory><Name>Документ, удостоверяющий личность иностранного гражданина</Name><Actual>true</Actual></Type>
+<Type><ID>4</ID><IDCategory>2</IDCategory><Name>Аттестат о среднем общем образовании</Name><Actual>true</Actual></Type>
+<Type><ID>5</ID><IDCategory>2</IDCategory><Name>Диплом о среднем профессиональном образовании</Name><Actual>true</Actual></Type>
+<Type><ID>6</ID><IDCategory>2</IDCategory><Name>Диплом бакалавра</Name><Actual>true</Actual></Type>
+<Type><ID>7</ID><IDCategory>3</IDCategory><Name>Справка об установлении инвалидности</Name><Actual>true</Actual></Type>
+<Type><ID>8</ID><IDCategory>4</IDCategory><Name>Удостоверение о наличии золотого знака отличия ГТО</Name><Actual>true</Actual></Type>
+</DocumentTypes>
+`,
+	"EduLevelsCampaignTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<EduLevelsCampaignTypes>
+<EduLevelCampaignType><ID>1</ID><IDCampaignType>1</IDCampaignType><IDEducationLevel>2</IDEducationLevel><Actual>true</Actual></EduLevelCampaignType>
+<EduLevelCampaignType><ID>2</ID><IDCampaignType>1</IDCampaignType><IDEducationLevel>3</IDEducationLevel><Actual>true</Actual></EduLevelCampaignType>
+<EduLevelCampaignType><ID>3</ID><IDCampaignType>2</IDCampaignType><IDEducationLevel>4</IDEducationLevel><Actual>true</Actual></EduLevelCampaignType>
+<EduLevelCampaignType><ID>4</ID><IDCampaignType>3</IDCampaignType><IDEducationLevel>5</IDEducationLevel><Actual>true</Actual></EduLevelCampaignType>
+<EduLevelCampaignType><ID>5</ID><IDCampaignType>4</IDCampaignType><IDEducationLevel>1</IDEducationLevel><Actual>true</Actual></EduLevelCampaignType>
+</EduLevelsCampaignTypes>
+`,
+	"EducationForm": `<?xml version="1.0" encoding="UTF-8"?>
+<EducationForm>
+<Form><ID>1</ID><Name>Очная</Name><Actual>true</Actual></Form>
+<Form><ID>2</ID><Name>Очно-заочная</Name><Actual>true</Actual></Form>
+<Form><ID>3</ID><Name>Заочная</Name><Actual>true</Actual></Form>
+</EducationForm>
+`,
+	"EducationLevel": `<?xml version="1.0" encoding="UTF-8"?>
+<EducationLevel>
+<Level><ID>1</ID><Name>Среднее профессиональное образование</Name><Actual>true</Actual></Level>
+<Level><ID>2</ID><Name>Бакалавриат</Name><Actual>true</Actual></Level>
+<Level><ID>3</ID><Name>Специалитет</Name><Actual>true</Actual></Level>
+<Level><ID>4</ID><Name>Магистратура</Name><Actual>true</Actual></Level>
+<Level><ID>5</ID><Name>Подготовка кадров высшей квалификации</Name><Actual>true</Actual></Level>
+</EducationLevel>
+`,
+	"EducationSource": `<?xml version="1.0" encoding="UTF-8"?>
+<EducationSource>
+<Source><ID>1</ID><Name>Бюджетные места</Name><Actual>true</Actual></Source>
+<Source><ID>2</ID><Name>Полное возмещение затрат</Name><Actual>true</Actual></Source>
+<Source><ID>3</ID><Name>Целевой прием</Name><Actual>true</Actual></Source>
+<Source><ID>4</ID><Name>Особая квота</Name><Actual>true</Actual></Source>
+</EducationSource>
+`,
+	"EntranceTestDocumentTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<EntranceTestDocumentTypes>
+<Type><ID>1</ID><Name>Свидетельство о результатах ЕГЭ</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Name>Диплом победителя/призера олимпиады школьников</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><Name>Диплом победителя/призера всероссийской олимпиады школьников</Name><Actual>true</Actual></Type>
+<Type><ID>4</ID><Name>Справка о результатах вступительного испытания</Name><Actual>true</Actual></Type>
+</EntranceTestDocumentTypes>
+`,
+	"EntranceTestResultSources": `<?xml version="1.0" encoding="UTF-8"?>
+<EntranceTestResultSources>
+<Source><ID>1</ID><Name>ЕГЭ</Name><Actual>true</Actual></Source>
+<Source><ID>2</ID><Name>Вступительное испытание организации</Name><Actual>true</Actual></Source>
+<Source><ID>3</ID><Name>Диплом олимпиады</Name><Actual>true</Actual></Source>
+<Source><ID>4</ID><Name>Результаты централизованного тестирования</Name><Actual>true</Actual></Source>
+</EntranceTestResultSources>
+`,
+	"EntranceTestType": `<?xml version="1.0" encoding="UTF-8"?>
+<EntranceTestType>
+<Type><ID>1</ID><Name>Вступительное испытание</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Name>ЕГЭ</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><Name>Дополнительное вступительное испытание творческой направленности</Name><Actual>true</Actual></Type>
+<Type><ID>4</ID><Name>Дополнительное вступительное испытание профессиональной направленности</Name><Actual>true</Actual></Type>
+</EntranceTestType>
+`,
+	"Genders": `<?xml version="1.0" encoding="UTF-8"?>
+<Genders>
+<Gender><ID>1</ID><Name>Мужской</Name><Actual>true</Actual></Gender>
+<Gender><ID>2</ID><Name>Женский</Name><Actual>true</Actual></Gender>
+</Genders>
+`,
+	"LevelBudget": `<?xml version="1.0" encoding="UTF-8"?>
+<LevelBudget>
+<Budget><ID>1</ID><Code></Code><Name>Федеральный</Name><Actual>true</Actual></Budget>
+<Budget><ID>2</ID><Code></Code><Name>Региональный</Name><Actual>true</Actual></Budget>
+<Budget><ID>3</ID><Code></Code><Name>Муниципальный</Name><Actual>true</Actual></Budget>
+</LevelBudget>
+`,
+	"MilitaryCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<MilitaryCategories>
+<Category><ID>1</ID><Name>Военнослужащие по контракту</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Граждане, прошедшие военную службу по призыву</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Участники боевых действий</Name><Actual>true</Actual></Category>
+</MilitaryCategories>
+`,
+	"MinScoreSubjects": `<?xml version="1.0" encoding="UTF-8"?>
+<MinScoreSubjects>
+<MinScore><ID>1</ID><IDSubject>1</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>36</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>2</ID><IDSubject>2</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>27</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>3</ID><IDSubject>3</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>36</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>4</ID><IDSubject>4</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>36</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>5</ID><IDSubject>5</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>36</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>6</ID><IDSubject>6</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>32</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>7</ID><IDSubject>7</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>42</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>8</ID><IDSubject>8</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>40</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>9</ID><IDSubject>9</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>37</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>10</ID><IDSubject>10</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>32</MinScore><Actual>true</Actual></MinScore>
+<MinScore><ID>11</ID><IDSubject>11</IDSubject><IDEducationLevel>2</IDEducationLevel><MinScore>22</MinScore><Actual>true</Actual></MinScore>
+</MinScoreSubjects>
+`,
+	"Okcms": `<?xml version="1.0" encoding="UTF-8"?>
+<Okcms>
+<Okcm><ID>643</ID><Code>643</Code><ShortName>Россия</ShortName><Name>Российская Федерация</Name><Actual>true</Actual></Okcm>
+<Okcm><ID>112</ID><Code>112</Code><ShortName>Беларусь</ShortName><Name>Республика Беларусь</Name><Actual>true</Actual></Okcm>
+<Okcm><ID>398</ID><Code>398</Code><ShortName>Казахстан</ShortName><Name>Республика Казахстан</Name><Actual>true</Actual></Okcm>
+<Okcm><ID>860</ID><Code>860</Code><ShortName>Узбекистан</ShortName><Name>Республика Узбекистан</Name><Actual>true</Actual></Okcm>
+</Okcms>
+`,
+	"Oktmos": `<?xml version="1.0" encoding="UTF-8"?>
+<Oktmos>
+<Oktmo><ID>1</ID><Code>45000000</Code><Name>Москва</Name><IDRegion>77</IDRegion><Actual>true</Actual></Oktmo>
+<Oktmo><ID>2</ID><Code>40000000</Code><Name>Санкт-Петербург</Name><IDRegion>78</IDRegion><Actual>true</Actual></Oktmo>
+<Oktmo><ID>3</ID><Code>50701000</Code><Name>Балашиха</Name><IDRegion>50</IDRegion><Actual>true</Actual></Oktmo>
+</Oktmos>
+`,
+	"OlyProfiles": `<?xml version="1.0" encoding="UTF-8"?>
+<OlyProfiles>
+<Profile><ID>1</ID><Name>Математика</Name><Actual>true</Actual></Profile>
+<Profile><ID>2</ID><Name>Физика</Name><Actual>true</Actual></Profile>
+<Profile><ID>3</ID><Name>Информатика</Name><Actual>true</Actual></Profile>
+<Profile><ID>4</ID><Name>Химия</Name><Actual>true</Actual></Profile>
+<Profile><ID>5</ID><Name>Биология</Name><Actual>true</Actual></Profile>
+<Profile><ID>6</ID><Name>Русский язык</Name><Actual>true</Actual></Profile>
+</OlyProfiles>
+`,
+	"OlympicDiplomaType": `<?xml version="1.0" encoding="UTF-8"?>
+<OlympicDiplomaType>
+<DiplomaType><ID>1</ID><Name>Победитель</Name><Actual>true</Actual></DiplomaType>
+<DiplomaType><ID>2</ID><Name>Призер</Name><Actual>true</Actual></DiplomaType>
+</OlympicDiplomaType>
+`,
+	"OlympicLevel": `<?xml version="1.0" encoding="UTF-8"?>
+<OlympicLevel>
+<Level><ID>1</ID><Name>I уровень</Name><Actual>true</Actual></Level>
+<Level><ID>2</ID><Name>II уровень</Name><Actual>true</Actual></Level>
+<Level><ID>3</ID><Name>III уровень</Name><Actual>true</Actual></Level>
+<Level><ID>4</ID><Name>Всероссийская олимпиада школьников</Name><Actual>true</Actual></Level>
+</OlympicLevel>
+`,
+	"OlympicMinEge": `<?xml version="1.0" encoding="UTF-8"?>
+<OlympicMinEge>
+<MinEge><ID>1</ID><OlympicNumber>1</OlympicNumber><IDSubject>2</IDSubject><MinEge>75</MinEge><Actual>true</Actual></MinEge>
+<MinEge><ID>2</ID><OlympicNumber>2</OlympicNumber><IDSubject>3</IDSubject><MinEge>75</MinEge><Actual>true</Actual></MinEge>
+<MinEge><ID>3</ID><OlympicNumber>3</OlympicNumber><IDSubject>8</IDSubject><MinEge>75</MinEge><Actual>true</Actual></MinEge>
+</OlympicMinEge>
+`,
+	"Olympics": `<?xml version="1.0" encoding="UTF-8"?>
+<Olympics>
+<Olympic><ID>1</ID><OlympicNumber>1</OlympicNumber><Name>Олимпиада школьников «Высшая проба»</Name><Year>2020</Year><IDLevel>1</IDLevel><Actual>true</Actual></Olympic>
+<Olympic><ID>2</ID><OlympicNumber>2</OlympicNumber><Name>Олимпиада школьников «Физтех»</Name><Year>2020</Year><IDLevel>1</IDLevel><Actual>true</Actual></Olympic>
+<Olympic><ID>3</ID><OlympicNumber>3</OlympicNumber><Name>Открытая олимпиада школьников по программированию</Name><Year>2020</Year><IDLevel>2</IDLevel><Actual>true</Actual></Olympic>
+</Olympics>
+`,
+	"OlympicsProfiles": `<?xml version="1.0" encoding="UTF-8"?>
+<OlympicsProfiles>
+<Profile><ID>1</ID><IDOlympic>1</IDOlympic><Name>Математика</Name><Actual>true</Actual></Profile>
+<Profile><ID>2</ID><IDOlympic>2</IDOlympic><Name>Физика</Name><Actual>true</Actual></Profile>
+<Profile><ID>3</ID><IDOlympic>3</IDOlympic><Name>Информатика</Name><Actual>true</Actual></Profile>
+</OlympicsProfiles>
+`,
+	"OrderAdmissionStatuses": `<?xml version="1.0" encoding="UTF-8"?>
+<OrderAdmissionStatuses>
+<Status><ID>1</ID><Code>new</Code><Name>Новый</Name><Actual>true</Actual></Status>
+<Status><ID>2</ID><Code>published</Code><Name>Опубликован</Name><Actual>true</Actual></Status>
+<Status><ID>3</ID><Code>canceled</Code><Name>Отменен</Name><Actual>true</Actual></Status>
+</OrderAdmissionStatuses>
+`,
+	"OrderAdmissionTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<OrderAdmissionTypes>
+<Type><ID>1</ID><Code>admission</Code><Name>Приказ о зачислении</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Code>exclusion</Code><Name>Приказ об исключении из приказа о зачислении</Name><Actual>true</Actual></Type>
+</OrderAdmissionTypes>
+`,
+	"OrphanCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<OrphanCategories>
+<Category><ID>1</ID><Name>Дети-сироты</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Дети, оставшиеся без попечения родителей</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Лица из числа детей-сирот и детей, оставшихся без попечения родителей</Name><Actual>true</Actual></Category>
+</OrphanCategories>
+`,
+	"ParentsLostCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<ParentsLostCategories>
+<Category><ID>1</ID><Name>Дети военнослужащих, погибших при исполнении обязанностей военной службы</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Дети сотрудников органов внутренних дел, погибших при исполнении служебных обязанностей</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Дети прокурорских работников, погибших при исполнении служебных обязанностей</Name><Actual>true</Actual></Category>
+</ParentsLostCategories>
+`,
+	"RadiationWorkCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<RadiationWorkCategories>
+<Category><ID>1</ID><Name>Пострадавшие в результате катастрофы на Чернобыльской АЭС</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Пострадавшие в результате аварии на производственном объединении «Маяк»</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Пострадавшие в результате ядерных испытаний на Семипалатинском полигоне</Name><Actual>true</Actual></Category>
+</RadiationWorkCategories>
+`,
+	"Regions": `<?xml version="1.0" encoding="UTF-8"?>
+<Regions>
+<Region><ID>77</ID><Code>77</Code><Name>г. Москва</Name><Actual>true</Actual></Region>
+<Region><ID>78</ID><Code>78</Code><Name>г. Санкт-Петербург</Name><Actual>true</Actual></Region>
+<Region><ID>50</ID><Code>50</Code><Name>Московская область</Name><Actual>true</Actual></Region>
+<Region><ID>16</ID><Code>16</Code><Name>Республика Татарстан</Name><Actual>true</Actual></Region>
+<Region><ID>66</ID><Code>66</Code><Name>Свердловская область</Name><Actual>true</Actual></Region>
+</Regions>
+`,
+	"ReturnTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<ReturnTypes>
+<Type><ID>1</ID><Name>Лично</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Name>Почтой</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><Name>Через ЕПГУ</Name><Actual>true</Actual></Type>
+</ReturnTypes>
+`,
+	"Subject": `<?xml version="1.0" encoding="UTF-8"?>
+<Subject>
+<Subject><ID>1</ID><Name>Русский язык</Name><Actual>true</Actual></Subject>
+<Subject><ID>2</ID><Name>Математика</Name><Actual>true</Actual></Subject>
+<Subject><ID>3</ID><Name>Физика</Name><Actual>true</Actual></Subject>
+<Subject><ID>4</ID><Name>Химия</Name><Actual>true</Actual></Subject>
+<Subject><ID>5</ID><Name>Биология</Name><Actual>true</Actual></Subject>
+<Subject><ID>6</ID><Name>История</Name><Actual>true</Actual></Subject>
+<Subject><ID>7</ID><Name>Обществознание</Name><Actual>true</Actual></Subject>
+<Subject><ID>8</ID><Name>Информатика и ИКТ</Name><Actual>true</Actual></Subject>
+<Subject><ID>9</ID><Name>География</Name><Actual>true</Actual></Subject>
+<Subject><ID>10</ID><Name>Литература</Name><Actual>true</Actual></Subject>
+<Subject><ID>11</ID><Name>Иностранный язык</Name><Actual>true</Actual></Subject>
+</Subject>
+`,
+	"VeteranCategories": `<?xml version="1.0" encoding="UTF-8"?>
+<VeteranCategories>
+<Category><ID>1</ID><Name>Ветераны боевых действий</Name><Actual>true</Actual></Category>
+<Category><ID>2</ID><Name>Инвалиды войны</Name><Actual>true</Actual></Category>
+<Category><ID>3</ID><Name>Участники Великой Отечественной войны</Name><Actual>true</Actual></Category>
+</VeteranCategories>
+`,
+	"ViolationTypes": `<?xml version="1.0" encoding="UTF-8"?>
+<ViolationTypes>
+<Type><ID>1</ID><Name>Нарушение порядка проведения вступительного испытания</Name><Actual>true</Actual></Type>
+<Type><ID>2</ID><Name>Использование средств связи</Name><Actual>true</Actual></Type>
+<Type><ID>3</ID><Name>Использование справочных материалов</Name><Actual>true</Actual></Type>
+</ViolationTypes>
+`,
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//Endpoint Path of the emulated method relative to the api base.
+type Endpoint string
+
+const (
+	EndpointClsRequest   Endpoint = "cls/request"
+	EndpointTokenNew     Endpoint = "token/new"
+	EndpointTokenInfo    Endpoint = "token/info"
+	EndpointTokenConfirm Endpoint = "token/confirm"
+)
+
+var AllEndpoint = []Endpoint{
+	EndpointClsRequest,
+	EndpointTokenNew,
+	EndpointTokenInfo,
+	EndpointTokenConfirm,
+}
+
+func (e Endpoint) IsValid() bool {
+	switch e {
+	case EndpointClsRequest,
+		EndpointTokenNew,
+		EndpointTokenInfo,
+		EndpointTokenConfirm:
+		return true
+	}
+	return false
+}
+
+func (e Endpoint) String() string {
+	return string(e)
+}
+
+//Fault Rule of the failure of the endpoint, the zero value does nothing.
+//Latency and Status apply to any endpoint, the remaining failures only to the endpoints where they make sense.
+type Fault struct {
+	//Latency Fixed delay before the response.
+	Latency time.Duration
+	//Jitter Random delay in the range [0, Jitter) added to Latency.
+	Jitter time.Duration
+	//Status Response code instead of the normal response, for example fiber.StatusServiceUnavailable.
+	Status int
+	//MalformedJSON Respond with the cut JSON body.
+	MalformedJSON bool
+	//InvalidSignature token/info: the signature of the ResponseToken does not match its data.
+	InvalidSignature bool
+	//WrongOGRN token/info: the OGRN in the header of the ResponseToken, empty keeps the OGRN of the server.
+	WrongOGRN string
+	//DropConfirm token/confirm: respond with success but keep the message in the queue.
+	DropConfirm bool
+	//Duplicate token/new: put the message into the queue twice.
+	Duplicate bool
+	//Probability Chance of the failure in the range (0, 1], zero means always.
+	Probability float64
+	//Times Number of the failures after which the rule is removed, zero means unlimited.
+	Times int
+}
+
+//SetFault Register the failure rule of the endpoint, it replaces the previous rule of the endpoint.
+func (s *Server) SetFault(endpoint Endpoint, fault Fault) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	if s.faults == nil {
+		s.faults = map[Endpoint]*Fault{}
+	}
+	s.faults[endpoint] = &fault
+}
+
+//DeleteFault Remove the failure rule of the endpoint.
+func (s *Server) DeleteFault(endpoint Endpoint) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	delete(s.faults, endpoint)
+}
+
+//ResetFaults Remove the failure rules of all endpoints.
+func (s *Server) ResetFaults() {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	s.faults = nil
+}
+
+//takeFault Receive the rule of the endpoint if it fires on this request.
+func (s *Server) takeFault(endpoint Endpoint) (Fault, bool) {
+	s.faultMu.Lock()
+	defer s.faultMu.Unlock()
+	f, ok := s.faults[endpoint]
+	if !ok {
+		return Fault{}, false
+	}
+	if f.Probability > 0 && rand.Float64() >= f.Probability {
+		return Fault{}, false
+	}
+	if f.Times > 0 {
+		f.Times--
+		if f.Times == 0 {
+			delete(s.faults, endpoint)
+		}
+	}
+	return *f, true
+}
+
+const faultLocal = "fault"
+
+//withFault Wrap the handler of the endpoint with its failure rule.
+func (s *Server) withFault(endpoint Endpoint, handler fiber.Handler) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		f, ok := s.takeFault(endpoint)
+		if !ok {
+			return handler(ctx)
+		}
+
+		delay := f.Latency
+		if f.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(f.Jitter)))
+		}
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		if f.Status != 0 {
+			return apiError(f.Status, http.StatusText(f.Status))
+		}
+		if f.MalformedJSON {
+			ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return ctx.SendString(`{"IDJWT":"`)
+		}
+
+		ctx.Locals(faultLocal, f)
+		return handler(ctx)
+	}
+}
+
+//firedFault Receive the failure rule fired on the request, the zero value if none.
+func firedFault(ctx *fiber.Ctx) Fault {
+	f, _ := ctx.Locals(faultLocal).(Fault)
+	return f
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func addMessage(t *testing.T, ts *TestServer) int {
+	t.Helper()
+	msg, err := ts.Enqueue(message.ActionAdd, message.DatatypeCampaign, []byte("<PackageData/>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg.IDJWT
+}
+
+func TestServer_SetFault(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		endpoint Endpoint
+		fault    Fault
+		send     func(ts *TestServer) sspvo.Response
+		check    func(t *testing.T, ts *TestServer, data []byte, err error)
+	}{
+		{
+			name:     "status",
+			endpoint: EndpointClsRequest,
+			fault:    Fault{Status: fiber.StatusServiceUnavailable},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewCLSMessage(message.CLSGenders))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if err == nil || !strings.Contains(err.Error(), "503") {
+					t.Errorf("Data() = %s, error = %v, want 503", data, err)
+				}
+			},
+		},
+		{
+			name:     "latency",
+			endpoint: EndpointClsRequest,
+			fault:    Fault{Latency: 50 * time.Millisecond, Jitter: 10 * time.Millisecond},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewCLSMessage(message.CLSGenders))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if err != nil {
+					t.Errorf("Data() error = %v", err)
+				}
+			},
+		},
+		{
+			name:     "malformed json",
+			endpoint: EndpointTokenInfo,
+			fault:    Fault{MalformedJSON: true},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, addMessage(t, ts)))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if err == nil {
+					t.Errorf("Data() = %s, must fail", data)
+				}
+			},
+		},
+		{
+			name:     "invalid signature",
+			endpoint: EndpointTokenInfo,
+			fault:    Fault{InvalidSignature: true},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, addMessage(t, ts)))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if !errors.Is(err, sspvo.ErrBadSign) {
+					t.Errorf("Data() = %s, error = %v, want %v", data, err, sspvo.ErrBadSign)
+				}
+			},
+		},
+		{
+			name:     "wrong ogrn",
+			endpoint: EndpointTokenInfo,
+			fault:    Fault{WrongOGRN: "1027700000000"},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, addMessage(t, ts)))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if err != nil {
+					t.Fatalf("Data() error = %v", err)
+				}
+				resp := struct {
+					ResponseToken string `json:"ResponseToken"`
+				}{}
+				if err = json.Unmarshal(data, &resp); err != nil {
+					t.Fatal(err)
+				}
+				header, err := base64.StdEncoding.DecodeString(strings.Split(resp.ResponseToken, ".")[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(header), `"OGRN":"1027700000000"`) {
+					t.Errorf("ResponseToken header = %s, want wrong OGRN", header)
+				}
+			},
+		},
+		{
+			name:     "drop confirm",
+			endpoint: EndpointTokenConfirm,
+			fault:    Fault{DropConfirm: true},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewConfirmMessage(ts.Crypto, addMessage(t, ts)))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if err != nil {
+					t.Errorf("Data() error = %v", err)
+				}
+				if len(ts.Queue()) != 1 {
+					t.Errorf("Queue() = %+v, the message must stay in the queue", ts.Queue())
+				}
+			},
+		},
+		{
+			name:     "duplicate",
+			endpoint: EndpointTokenNew,
+			fault:    Fault{Duplicate: true},
+			send: func(ts *TestServer) sspvo.Response {
+				return ts.Client.Send(ctx, message.NewActionMessage(ts.Crypto, message.ActionAdd, message.DatatypeCampaign, []byte("<PackageData/>")))
+			},
+			check: func(t *testing.T, ts *TestServer, data []byte, err error) {
+				if err != nil {
+					t.Errorf("Data() error = %v", err)
+				}
+				queue := ts.Queue()
+				if len(queue) != 2 || string(queue[0].Data) != string(queue[1].Data) {
+					t.Errorf("Queue() = %+v, want the duplicated message", queue)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := Start(t, SetEndpointFault(tt.endpoint, tt.fault))
+			data, err := tt.send(ts).Data()
+			tt.check(t, ts, data, err)
+		})
+	}
+}
+
+func TestServer_SetFault_times(t *testing.T) {
+	ts := Start(t)
+	ts.SetFault(EndpointClsRequest, Fault{Status: fiber.StatusBadGateway, Times: 1})
+
+	cls := message.NewCLSMessage(message.CLSGenders)
+	if _, err := ts.Client.Send(context.Background(), cls).Data(); err == nil {
+		t.Errorf("first Data() must fail")
+	}
+	if _, err := ts.Client.Send(context.Background(), cls).Data(); err != nil {
+		t.Errorf("second Data() error = %v, the rule must be removed", err)
+	}
+
+	ts.SetFault(EndpointClsRequest, Fault{Status: fiber.StatusBadGateway})
+	ts.ResetFaults()
+	if _, err := ts.Client.Send(context.Background(), cls).Data(); err != nil {
+		t.Errorf("Data() after ResetFaults() error = %v", err)
+	}
+}
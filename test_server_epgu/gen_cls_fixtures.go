@@ -0,0 +1,60 @@
+//go:build ignore
+//+build ignore
+
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+//The program generates cls_fixtures.go from the classifier responses in fixtures/cls.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	files, err := filepath.Glob(filepath.Join("fixtures", "cls", "*.xml"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`// Code generated by go run gen_cls_fixtures.go; DO NOT EDIT.
+
+package test_server_epgu
+
+import "github.com/ftomza/go-sspvo/message"
+
+var clsFixtures = map[message.CLS]string{
+`)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		value := strconv.Quote(string(data))
+		if !bytes.ContainsRune(data, '`') {
+			value = "`" + string(data) + "`"
+		}
+		fmt.Fprintf(&buf, "%q: %s,\n", strings.TrimSuffix(filepath.Base(file), ".xml"), value)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile("cls_fixtures.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
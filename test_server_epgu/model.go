@@ -0,0 +1,287 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+//entitySpec Description of the records of the datatype processed by the emulator.
+type entitySpec struct {
+	element string
+	key     string
+	refs    []entityRef
+}
+
+//entityRef Field of the record with the key of the record of other datatype.
+type entityRef struct {
+	field    string
+	datatype message.Datatype
+}
+
+var entitySpecs = map[message.Datatype]entitySpec{
+	message.DatatypeSubdivisionOrg: {element: "SubdivisionOrg", key: "UID"},
+	message.DatatypeCampaign:       {element: "Campaign", key: "UID"},
+	message.DatatypeAdmissionVolume: {element: "AdmissionVolume", key: "UID", refs: []entityRef{
+		{field: "UIDCampaign", datatype: message.DatatypeCampaign},
+	}},
+	message.DatatypeCompetitiveGroups: {element: "CompetitiveGroup", key: "UID", refs: []entityRef{
+		{field: "UIDCampaign", datatype: message.DatatypeCampaign},
+		{field: "UIDSubdivisionOrg", datatype: message.DatatypeSubdivisionOrg},
+	}},
+	message.DatatypeCompetitiveGroupPrograms: {element: "CompetitiveGroupProgram", key: "UID", refs: []entityRef{
+		{field: "UIDCompetitiveGroup", datatype: message.DatatypeCompetitiveGroups},
+	}},
+	message.DatatypeCompetitiveBenefits: {element: "CompetitiveBenefit", key: "UID", refs: []entityRef{
+		{field: "UIDCompetitiveGroup", datatype: message.DatatypeCompetitiveGroups},
+	}},
+	message.DatatypeEntranceTests: {element: "EntranceTest", key: "UID", refs: []entityRef{
+		{field: "UIDCompetitiveGroup", datatype: message.DatatypeCompetitiveGroups},
+	}},
+	message.DatatypeEntranceTestBenefits: {element: "EntranceTestBenefit", key: "UID", refs: []entityRef{
+		{field: "UIDEntranceTest", datatype: message.DatatypeEntranceTests},
+	}},
+	message.DatatypeEntrants: {element: "Entrant", key: "GUID"},
+	message.DatatypeIdentification: {element: "Identification", key: "UID", refs: []entityRef{
+		{field: "GUIDEntrant", datatype: message.DatatypeEntrants},
+	}},
+	message.DatatypeEducations: {element: "Education", key: "UID", refs: []entityRef{
+		{field: "GUIDEntrant", datatype: message.DatatypeEntrants},
+	}},
+	message.DatatypeEge: {element: "Ege", key: "UID", refs: []entityRef{
+		{field: "GUIDEntrant", datatype: message.DatatypeEntrants},
+	}},
+	message.DatatypeApplications: {element: "Application", key: "UID", refs: []entityRef{
+		{field: "UIDCompetitiveGroup", datatype: message.DatatypeCompetitiveGroups},
+		{field: "GUIDEntrant", datatype: message.DatatypeEntrants},
+	}},
+	message.DatatypeAchievements: {element: "Achievement", key: "UID", refs: []entityRef{
+		{field: "UIDCampaign", datatype: message.DatatypeCampaign},
+	}},
+	message.DatatypeAppAchievements: {element: "AppAchievement", key: "UID", refs: []entityRef{
+		{field: "UIDApplication", datatype: message.DatatypeApplications},
+		{field: "UIDAchievement", datatype: message.DatatypeAchievements},
+	}},
+}
+
+type packageField struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type packageRecord struct {
+	XMLName xml.Name
+	Fields  []packageField `xml:",any"`
+	Inner   []byte         `xml:",innerxml"`
+}
+
+func (r packageRecord) field(name string) string {
+	for _, f := range r.Fields {
+		if f.XMLName.Local == name {
+			return strings.TrimSpace(f.Value)
+		}
+	}
+	return ""
+}
+
+func (r packageRecord) raw() []byte {
+	return []byte(fmt.Sprintf("<%s>%s</%s>", r.XMLName.Local, r.Inner, r.XMLName.Local))
+}
+
+type packageData struct {
+	XMLName xml.Name        `xml:"PackageData"`
+	Records []packageRecord `xml:",any"`
+}
+
+//entityStore In-memory state of the organization built from the processed messages.
+type entityStore map[message.Datatype]map[string][]byte
+
+//Entity Receive the XML of the record of the datatype by its key (UID, GUID for entrants) stored by the processed messages.
+func (s *Server) Entity(datatype message.Datatype, key string) ([]byte, bool) {
+	s.entitiesMu.RLock()
+	defer s.entitiesMu.RUnlock()
+	data, ok := s.entities[datatype][key]
+	return data, ok
+}
+
+//Entities Receive the sorted keys of the records of the datatype stored by the processed messages.
+func (s *Server) Entities(datatype message.Datatype) []string {
+	s.entitiesMu.RLock()
+	defer s.entitiesMu.RUnlock()
+	keys := make([]string, 0, len(s.entities[datatype]))
+	for key := range s.entities[datatype] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+//process Apply the PackageData to the stored records the way the service does, the package is applied entirely or not at all.
+//Datatypes without the description are accepted without processing.
+func (s *Server) process(action message.Action, datatype message.Datatype, data []byte) (result []byte, errs []string) {
+	spec, ok := entitySpecs[datatype]
+	if !ok {
+		return nil, nil
+	}
+
+	pkg := packageData{}
+	if err := xml.Unmarshal(bytes.TrimSpace(data), &pkg); err != nil {
+		return nil, []string{fmt.Sprintf("Неверный формат PackageData: %v", err)}
+	}
+
+	var records []packageRecord
+	for _, r := range pkg.Records {
+		if r.XMLName.Local != spec.element {
+			errs = append(errs, fmt.Sprintf("Неизвестный элемент %s, ожидается %s", r.XMLName.Local, spec.element))
+			continue
+		}
+		records = append(records, r)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	s.entitiesMu.Lock()
+	defer s.entitiesMu.Unlock()
+	if s.entities == nil {
+		s.entities = entityStore{}
+	}
+	stored := s.entities[datatype]
+
+	if action == message.ActionGet {
+		return s.entities.get(spec, datatype, records)
+	}
+
+	if len(records) == 0 {
+		return nil, []string{fmt.Sprintf("Пакет не содержит записей %s", spec.element)}
+	}
+
+	seen := map[string]bool{}
+	for _, r := range records {
+		key := r.field(spec.key)
+		switch {
+		case key == "":
+			errs = append(errs, fmt.Sprintf("Не указан %s записи %s", spec.key, spec.element))
+			continue
+		case seen[key]:
+			errs = append(errs, fmt.Sprintf("%s %s повторяется в пакете", spec.key, key))
+			continue
+		}
+		seen[key] = true
+
+		_, exists := stored[key]
+		switch action {
+		case message.ActionAdd:
+			if exists {
+				errs = append(errs, fmt.Sprintf("Запись %s с %s %s уже существует", spec.element, spec.key, key))
+			}
+		case message.ActionEdit, message.ActionRemove:
+			if !exists {
+				errs = append(errs, fmt.Sprintf("Запись %s с %s %s не найдена", spec.element, spec.key, key))
+			}
+		}
+
+		if action == message.ActionRemove {
+			errs = append(errs, s.entities.usages(datatype, key)...)
+			continue
+		}
+		for _, ref := range spec.refs {
+			refKey := r.field(ref.field)
+			if refKey == "" {
+				continue
+			}
+			if _, ok := s.entities[ref.datatype][refKey]; !ok {
+				errs = append(errs, fmt.Sprintf("Не найдена запись %s с %s %s", entitySpecs[ref.datatype].element, entitySpecs[ref.datatype].key, refKey))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if stored == nil {
+		stored = map[string][]byte{}
+		s.entities[datatype] = stored
+	}
+	for _, r := range records {
+		if action == message.ActionRemove {
+			delete(stored, r.field(spec.key))
+			continue
+		}
+		stored[r.field(spec.key)] = r.raw()
+	}
+	return nil, nil
+}
+
+//get Receive the PackageData with the requested records, all records of the datatype if none are requested.
+func (m entityStore) get(spec entitySpec, datatype message.Datatype, records []packageRecord) ([]byte, []string) {
+	stored := m[datatype]
+
+	var keys []string
+	for _, r := range records {
+		keys = append(keys, r.field(spec.key))
+	}
+	if len(keys) == 0 {
+		for key := range stored {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+
+	buf := bytes.NewBufferString("<PackageData>")
+	var errs []string
+	for _, key := range keys {
+		data, ok := stored[key]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("Запись %s с %s %s не найдена", spec.element, spec.key, key))
+			continue
+		}
+		buf.Write(data)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	buf.WriteString("</PackageData>")
+	return buf.Bytes(), nil
+}
+
+//usages Receive the errors about the records referencing the record being removed.
+func (m entityStore) usages(datatype message.Datatype, key string) (errs []string) {
+	var datatypes []string
+	for dt := range entitySpecs {
+		datatypes = append(datatypes, dt.String())
+	}
+	sort.Strings(datatypes)
+
+	for _, dt := range datatypes {
+		spec := entitySpecs[message.Datatype(dt)]
+		for _, ref := range spec.refs {
+			if ref.datatype != datatype {
+				continue
+			}
+			var users []string
+			for userKey, data := range m[message.Datatype(dt)] {
+				r := packageRecord{}
+				if err := xml.Unmarshal(data, &r); err == nil && r.field(ref.field) == key {
+					users = append(users, userKey)
+				}
+			}
+			sort.Strings(users)
+			for _, user := range users {
+				errs = append(errs, fmt.Sprintf("Запись с %s %s используется в %s %s", entitySpecs[datatype].key, key, spec.element, user))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func TestServer_process(t *testing.T) {
+	s, err := NewServerDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		action     message.Action
+		datatype   message.Datatype
+		data       string
+		want       bool
+		wantErrors []string
+		wantData   string
+	}{
+		{
+			name:     "add subdivision",
+			action:   message.ActionAdd,
+			datatype: message.DatatypeSubdivisionOrg,
+			data:     `<?xml version="1.0" encoding="utf-8"?><PackageData><SubdivisionOrg><UID>S1</UID><Name>Подвал</Name></SubdivisionOrg></PackageData>`,
+			want:     true,
+		},
+		{
+			name:       "fail add duplicate uid",
+			action:     message.ActionAdd,
+			datatype:   message.DatatypeSubdivisionOrg,
+			data:       `<PackageData><SubdivisionOrg><UID>S1</UID></SubdivisionOrg></PackageData>`,
+			wantErrors: []string{"Запись SubdivisionOrg с UID S1 уже существует"},
+		},
+		{
+			name:       "fail add uid repeated in package",
+			action:     message.ActionAdd,
+			datatype:   message.DatatypeCampaign,
+			data:       `<PackageData><Campaign><UID>C1</UID></Campaign><Campaign><UID>C1</UID></Campaign></PackageData>`,
+			wantErrors: []string{"UID C1 повторяется в пакете"},
+		},
+		{
+			name:     "add campaign",
+			action:   message.ActionAdd,
+			datatype: message.DatatypeCampaign,
+			data:     `<PackageData><Campaign><UID>C1</UID><Name>Бакалавриат</Name></Campaign></PackageData>`,
+			want:     true,
+		},
+		{
+			name:       "fail add group unknown campaign",
+			action:     message.ActionAdd,
+			datatype:   message.DatatypeCompetitiveGroups,
+			data:       `<PackageData><CompetitiveGroup><UID>G1</UID><UIDCampaign>C2</UIDCampaign></CompetitiveGroup></PackageData>`,
+			wantErrors: []string{"Не найдена запись Campaign с UID C2"},
+		},
+		{
+			name:     "add group",
+			action:   message.ActionAdd,
+			datatype: message.DatatypeCompetitiveGroups,
+			data:     `<PackageData><CompetitiveGroup><UID>G1</UID><UIDCampaign>C1</UIDCampaign><UIDSubdivisionOrg>S1</UIDSubdivisionOrg></CompetitiveGroup></PackageData>`,
+			want:     true,
+		},
+		{
+			name:     "add entrant",
+			action:   message.ActionAdd,
+			datatype: message.DatatypeEntrants,
+			data:     `<PackageData><Entrant><GUID>E1</GUID></Entrant></PackageData>`,
+			want:     true,
+		},
+		{
+			name:     "add application",
+			action:   message.ActionAdd,
+			datatype: message.DatatypeApplications,
+			data:     `<PackageData><Application><UID>A1</UID><UIDCompetitiveGroup>G1</UIDCompetitiveGroup><GUIDEntrant>E1</GUIDEntrant></Application></PackageData>`,
+			want:     true,
+		},
+		{
+			name:       "fail remove used group",
+			action:     message.ActionRemove,
+			datatype:   message.DatatypeCompetitiveGroups,
+			data:       `<PackageData><CompetitiveGroup><UID>G1</UID></CompetitiveGroup></PackageData>`,
+			wantErrors: []string{"Запись с UID G1 используется в Application A1"},
+		},
+		{
+			name:       "fail edit unknown",
+			action:     message.ActionEdit,
+			datatype:   message.DatatypeCampaign,
+			data:       `<PackageData><Campaign><UID>C9</UID></Campaign></PackageData>`,
+			wantErrors: []string{"Запись Campaign с UID C9 не найдена"},
+		},
+		{
+			name:     "edit campaign",
+			action:   message.ActionEdit,
+			datatype: message.DatatypeCampaign,
+			data:     `<PackageData><Campaign><UID>C1</UID><Name>Магистратура</Name></Campaign></PackageData>`,
+			want:     true,
+		},
+		{
+			name:     "get campaign",
+			action:   message.ActionGet,
+			datatype: message.DatatypeCampaign,
+			data:     `<PackageData><Campaign><UID>C1</UID></Campaign></PackageData>`,
+			want:     true,
+			wantData: `<PackageData><Campaign><UID>C1</UID><Name>Магистратура</Name></Campaign></PackageData>`,
+		},
+		{
+			name:     "remove application",
+			action:   message.ActionRemove,
+			datatype: message.DatatypeApplications,
+			data:     `<PackageData><Application><UID>A1</UID></Application></PackageData>`,
+			want:     true,
+		},
+		{
+			name:       "fail unknown element",
+			action:     message.ActionAdd,
+			datatype:   message.DatatypeEntrants,
+			data:       `<PackageData><Campaign><UID>C1</UID></Campaign></PackageData>`,
+			wantErrors: []string{"Неизвестный элемент Campaign, ожидается Entrant"},
+		},
+		{
+			name:     "skip unknown datatype",
+			action:   message.ActionAdd,
+			datatype: message.DatatypeOther,
+			data:     `broken`,
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := s.Enqueue(tt.action, tt.datatype, []byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Result{}
+			if err = xml.Unmarshal(msg.Response, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Result != tt.want || strings.Join(got.Errors, "\n") != strings.Join(tt.wantErrors, "\n") {
+				t.Errorf("Enqueue() Result = %v, Errors = %q, want %v, %q", got.Result, got.Errors, tt.want, tt.wantErrors)
+			}
+			if tt.wantData != "" && !strings.Contains(string(msg.Response), tt.wantData) {
+				t.Errorf("Enqueue() Response = %s, want %s", msg.Response, tt.wantData)
+			}
+		})
+	}
+
+	if keys := s.Entities(message.DatatypeApplications); len(keys) != 0 {
+		t.Errorf("Entities() = %v, want empty", keys)
+	}
+	if data, ok := s.Entity(message.DatatypeCampaign, "C1"); !ok || !strings.Contains(string(data), "Магистратура") {
+		t.Errorf("Entity() = %s, %v", data, ok)
+	}
+}
+
+func TestStart_processResponse(t *testing.T) {
+	ts := Start(t)
+	ctx := context.Background()
+
+	add := message.NewActionMessage(ts.Crypto, message.ActionAdd, message.DatatypeSubdivisionOrg,
+		[]byte(`<PackageData><SubdivisionOrg><UID>S1</UID></SubdivisionOrg></PackageData>`))
+	for i := 0; i < 2; i++ {
+		if _, err := ts.Client.Send(ctx, add).Data(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queue := ts.Queue()
+	if len(queue) != 2 {
+		t.Fatalf("Queue() = %+v", queue)
+	}
+	data, err := ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, queue[1].IDJWT)).Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := struct {
+		ResponseToken string `json:"ResponseToken"`
+	}{}
+	if err = json.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(strings.Split(resp.ResponseToken, ".")[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Result{}
+	if err = xml.Unmarshal(payload, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Result || len(got.Errors) != 1 {
+		t.Errorf("Info Result = %+v, want the error of the duplicated UID", got)
+	}
+}
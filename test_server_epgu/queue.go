@@ -0,0 +1,309 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/message"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//QueueMessage Message accepted by token/new and its response waiting in the queue for confirmation.
+type QueueMessage struct {
+	IDJWT     int
+	Action    message.Action
+	Datatype  message.Datatype
+	Data      []byte
+	Response  []byte
+	Confirmed bool
+}
+
+//Result Response of the server on the message put into the queue.
+type Result struct {
+	XMLName  xml.Name `xml:"Response"`
+	IDJWT    int      `xml:"IDJWT"`
+	Action   string   `xml:"Action"`
+	DataType string   `xml:"DataType"`
+	Result   bool     `xml:"Result"`
+	//PackageData Records requested by the Get action, placed before Errors so it is not written into that element.
+	PackageData []byte   `xml:",innerxml"`
+	Errors      []string `xml:"Errors>Error,omitempty"`
+}
+
+type tokenHeader struct {
+	OGRN     string           `json:"OGRN"`
+	KPP      string           `json:"KPP"`
+	Cert64   string           `json:"Cert64"`
+	Action   message.Action   `json:"action"`
+	Datatype message.Datatype `json:"data_type"`
+	IDJWT    int              `json:"IDJWT"`
+}
+
+func apiError(status int, text string) error {
+	return fiber.NewError(status,
+		fmt.Sprintf("{\"Error\":\"{\\\"0\\\":\\\"field: OriginalErrorText message :%s\\\"}\"}", text))
+}
+
+//parseToken Check the signature of the token with the certificate of the organization and receive its header and payload.
+func (s *Server) parseToken(ctx *fiber.Ctx) (*tokenHeader, []byte, error) {
+	body := struct {
+		Token string `json:"token"`
+	}{}
+	if err := ctx.BodyParser(&body); err != nil {
+		return nil, nil, err
+	}
+
+	parts := strings.Split(strings.TrimSpace(body.Token), ".")
+	if len(parts) != 3 {
+		return nil, nil, apiError(fiber.StatusBadRequest, "Неверный формат токена")
+	}
+
+	headerData, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, apiError(fiber.StatusBadRequest, "Неверный формат заголовка токена")
+	}
+	header := &tokenHeader{}
+	if err = json.Unmarshal(headerData, header); err != nil {
+		return nil, nil, apiError(fiber.StatusBadRequest, "Неверный формат заголовка токена")
+	}
+
+	if err = s.checkAuth(header.OGRN, header.KPP); err != nil {
+		return nil, nil, err
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, apiError(fiber.StatusBadRequest, "Неверный формат данных токена")
+	}
+
+	sign, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, apiError(fiber.StatusBadRequest, "Неверный формат подписи токена")
+	}
+
+	digest := s.cryptoVerify.Hash([]byte(fmt.Sprintf("%s.%s", parts[0], parts[1])))
+	if ok, err := s.cryptoVerify.Verify(sign, digest); err != nil || !ok {
+		return nil, nil, apiError(fiber.StatusForbidden, "Подпись токена не прошла проверку")
+	}
+
+	return header, payload, nil
+}
+
+//responseToken Sign the response with the certificate of the server, the same way the service does.
+func (s *Server) responseToken(fields sspvo.JWTFields, payload []byte) (string, error) {
+	cert := s.Cert
+	if block, _ := pem.Decode([]byte(cert)); block != nil {
+		cert = base64.StdEncoding.EncodeToString(block.Bytes)
+	}
+	fields[sspvo.FieldCert] = cert
+
+	jsonHeader, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	header := base64.StdEncoding.EncodeToString(jsonHeader)
+	data := base64.StdEncoding.EncodeToString(payload)
+	sign, err := s.crypto.Sign(s.crypto.Hash([]byte(fmt.Sprintf("%s.%s", header, data))))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%s.%s", header, data, base64.StdEncoding.EncodeToString(sign)), nil
+}
+
+//breakSignature Replace the signature of the token with the signature of other data.
+func breakSignature(token string) string {
+	i := strings.LastIndex(token, ".")
+	sign, err := base64.StdEncoding.DecodeString(token[i+1:])
+	if err != nil || len(sign) == 0 {
+		return token
+	}
+	sign[0] ^= 0xff
+	return token[:i+1] + base64.StdEncoding.EncodeToString(sign)
+}
+
+//Enqueue Put the message into the queue as if it came through token/new and receive its IDJWT,
+//the PackageData of the known datatypes is applied to the stored records and the outcome is put into the Response.
+func (s *Server) Enqueue(action message.Action, datatype message.Datatype, data []byte) (QueueMessage, error) {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+
+	if s.Messages == nil {
+		s.Messages = map[int]interface{}{}
+	}
+	s.lastIDJWT++
+	msg := &QueueMessage{
+		IDJWT:    s.lastIDJWT,
+		Action:   action,
+		Datatype: datatype,
+		Data:     data,
+	}
+
+	packageData, errs := s.process(action, datatype, data)
+	res, err := xml.Marshal(Result{
+		IDJWT:       msg.IDJWT,
+		Action:      action.String(),
+		DataType:    datatype.String(),
+		Result:      len(errs) == 0,
+		Errors:      errs,
+		PackageData: packageData,
+	})
+	if err != nil {
+		return QueueMessage{}, err
+	}
+	msg.Response = append([]byte(xml.Header), res...)
+
+	s.Messages[msg.IDJWT] = msg
+	return *msg, nil
+}
+
+//Message Receive a copy of the message of the queue by IDJWT, confirmed messages are kept as well.
+func (s *Server) Message(idJWT int) (QueueMessage, bool) {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+	msg, ok := s.Messages[idJWT].(*QueueMessage)
+	if !ok {
+		return QueueMessage{}, false
+	}
+	return *msg, true
+}
+
+//Queue Receive copies of the messages waiting for confirmation in the order of arrival.
+func (s *Server) Queue() []QueueMessage {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+
+	var res []QueueMessage
+	for _, m := range s.Messages {
+		if msg, ok := m.(*QueueMessage); ok && !msg.Confirmed {
+			res = append(res, *msg)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].IDJWT < res[j].IDJWT
+	})
+	return res
+}
+
+func (s *Server) confirm(idJWT int) bool {
+	s.queueMu.Lock()
+	defer s.queueMu.Unlock()
+	msg, ok := s.Messages[idJWT].(*QueueMessage)
+	if !ok || msg.Confirmed {
+		return false
+	}
+	msg.Confirmed = true
+	return true
+}
+
+func (s *Server) apiTokenNew(ctx *fiber.Ctx) error {
+	header, payload, err := s.parseToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !header.Action.IsValid() || header.Action == message.Action("GetMessage") || header.Action == message.Action("MessageConfirm") {
+		return apiError(fiber.StatusBadRequest, "Неизвестное действие")
+	}
+	if !header.Datatype.IsValid() {
+		return apiError(fiber.StatusBadRequest, "Неизвестный тип данных")
+	}
+
+	msg, err := s.Enqueue(header.Action, header.Datatype, payload)
+	if err != nil {
+		return err
+	}
+	if firedFault(ctx).Duplicate {
+		if _, err = s.Enqueue(header.Action, header.Datatype, payload); err != nil {
+			return err
+		}
+	}
+
+	return ctx.JSON(map[string]string{"IDJWT": strconv.Itoa(msg.IDJWT)})
+}
+
+func (s *Server) apiTokenInfo(ctx *fiber.Ctx) error {
+	all := struct {
+		OGRN  string `json:"OGRN"`
+		KPP   string `json:"KPP"`
+		Token string `json:"token"`
+	}{}
+	if err := ctx.BodyParser(&all); err != nil {
+		return err
+	}
+
+	if all.Token == "" {
+		if err := s.checkAuth(all.OGRN, all.KPP); err != nil {
+			return err
+		}
+		ids := []int{}
+		for _, msg := range s.Queue() {
+			ids = append(ids, msg.IDJWT)
+		}
+		return ctx.JSON(map[string][]int{"Messages": ids})
+	}
+
+	header, _, err := s.parseToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	msg, ok := s.Message(header.IDJWT)
+	if !ok || msg.Confirmed {
+		return apiError(fiber.StatusNotFound, fmt.Sprintf("Сообщение %d не найдено", header.IDJWT))
+	}
+
+	f := firedFault(ctx)
+	ogrn := s.OGRN
+	if f.WrongOGRN != "" {
+		ogrn = f.WrongOGRN
+	}
+	token, err := s.responseToken(sspvo.JWTFields{
+		sspvo.FieldOGRN:     ogrn,
+		sspvo.FieldKPP:      s.KPP,
+		sspvo.FieldIdJWT:    msg.IDJWT,
+		sspvo.FieldAction:   msg.Action,
+		sspvo.FieldDataType: msg.Datatype,
+	}, msg.Response)
+	if err != nil {
+		return err
+	}
+	if f.InvalidSignature {
+		token = breakSignature(token)
+	}
+
+	return ctx.JSON(map[string]string{"ResponseToken": token})
+}
+
+func (s *Server) apiTokenConfirm(ctx *fiber.Ctx) error {
+	header, _, err := s.parseToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if firedFault(ctx).DropConfirm {
+		if msg, ok := s.Message(header.IDJWT); !ok || msg.Confirmed {
+			return apiError(fiber.StatusNotFound, fmt.Sprintf("Сообщение %d не найдено", header.IDJWT))
+		}
+	} else if !s.confirm(header.IDJWT) {
+		return apiError(fiber.StatusNotFound, fmt.Sprintf("Сообщение %d не найдено", header.IDJWT))
+	}
+
+	return ctx.JSON(map[string]string{"IDJWT": strconv.Itoa(header.IDJWT), "Result": "true"})
+}
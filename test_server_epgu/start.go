@@ -0,0 +1,177 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/client"
+	"github.com/ftomza/go-sspvo/crypto"
+	"github.com/ftomza/go-sspvo/message"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/gofiber/fiber/v2"
+)
+
+type options struct {
+	server       *Server
+	clientCrypto sspvo.Crypto
+	cls          map[message.CLS][]byte
+	faults       map[Endpoint]Fault
+}
+
+type Option func(*options)
+
+//SetServer To set the server option with own organization, certificates and fixtures, by default NewServerDefault.
+func SetServer(server *Server) Option {
+	return func(o *options) {
+		o.server = server
+	}
+}
+
+//SetClientCrypto To set the crypto option of the preconfigured client, by default the certificate CertOGRN with the key KeyOGRN.
+func SetClientCrypto(crypto sspvo.Crypto) Option {
+	return func(o *options) {
+		o.clientCrypto = crypto
+	}
+}
+
+//SetCLSResponse To set the XML response of the classifier, see Server.SetCLS.
+func SetCLSResponse(cls message.CLS, data []byte) Option {
+	return func(o *options) {
+		if o.cls == nil {
+			o.cls = map[message.CLS][]byte{}
+		}
+		o.cls[cls] = data
+	}
+}
+
+//SetEndpointFault To set the failure rule of the endpoint, see Server.SetFault.
+func SetEndpointFault(endpoint Endpoint, fault Fault) Option {
+	return func(o *options) {
+		if o.faults == nil {
+			o.faults = map[Endpoint]Fault{}
+		}
+		o.faults[endpoint] = fault
+	}
+}
+
+//TestServer Handle of the emulator started in the process of the test.
+type TestServer struct {
+	*Server
+	BaseURL string
+	Client  sspvo.Client
+	Crypto  sspvo.Crypto
+
+	app  *fiber.App
+	rest *resty.Client
+	ln   net.Listener
+	done chan struct{}
+}
+
+//Start Run an isolated emulator on a random local port for the test, it is closed automatically when the test ends,
+//supports the following options: SetServer, SetClientCrypto, SetCLSResponse, SetEndpointFault.
+func Start(t testing.TB, opts ...Option) *TestServer {
+	t.Helper()
+
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.server == nil {
+		server, err := NewServerDefault()
+		if err != nil {
+			t.Fatal(err)
+		}
+		o.server = server
+	}
+	for cls, data := range o.cls {
+		o.server.SetCLS(cls, data)
+	}
+	for endpoint, fault := range o.faults {
+		o.server.SetFault(endpoint, fault)
+	}
+
+	ts, err := start(o)
+	if err != nil {
+		t.Fatalf("test_server_epgu: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = ts.Close()
+	})
+	return ts
+}
+
+func start(o options) (*TestServer, error) {
+	s := o.server
+
+	app, err := s.app(fiber.Config{DisableStartupMessage: true, IdleTimeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	clientCrypto := o.clientCrypto
+	if clientCrypto == nil {
+		clientCrypto, err = crypto.NewGostCrypto(crypto.SetCert(s.CertOGRN), crypto.SetKey(s.KeyOGRN))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &TestServer{
+		Server:  s,
+		BaseURL: fmt.Sprintf("http://%s", ln.Addr().String()),
+		Crypto:  clientCrypto,
+		app:     app,
+		rest:    resty.New(),
+		ln:      ln,
+		done:    make(chan struct{}),
+	}
+
+	ts.Client, err = client.NewRestyClient(ts.rest,
+		client.SetAPIBase(ts.BaseURL+"/api"),
+		client.SetOGRN(s.OGRN),
+		client.SetKPP(s.KPP),
+		client.SetCrypto(clientCrypto),
+	)
+	if err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+
+	go func() {
+		defer close(ts.done)
+		_ = app.Listener(ln)
+	}()
+
+	return ts, nil
+}
+
+//Close Stop the emulator.
+func (ts *TestServer) Close() error {
+	select {
+	case <-ts.done:
+		return nil
+	default:
+	}
+	ts.rest.GetClient().CloseIdleConnections()
+	err := ts.app.Shutdown()
+	//the listener is closed as well in case the server has not started serving yet
+	_ = ts.ln.Close()
+	<-ts.done
+	return err
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/crypto"
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func TestStart(t *testing.T) {
+	ts := Start(t, SetCLSResponse(message.CLSGenders, []byte("<Genders/>")))
+	ctx := context.Background()
+
+	data, err := ts.Client.Send(ctx, message.NewCLSMessage(message.CLSGenders)).Data()
+	if err != nil || string(data) != "<Genders/>" {
+		t.Fatalf("CLS data = %s, error = %v", data, err)
+	}
+
+	data, err = ts.Client.Send(ctx, message.NewActionMessage(ts.Crypto, message.ActionAdd, message.DatatypeSubdivisionOrg,
+		[]byte("<PackageData><SubdivisionOrg><UID>1</UID></SubdivisionOrg></PackageData>"))).Data()
+	if err != nil {
+		t.Fatalf("Add data = %s, error = %v", data, err)
+	}
+	newMessage := struct {
+		IDJWT string `json:"IDJWT"`
+	}{}
+	if err = json.Unmarshal(data, &newMessage); err != nil {
+		t.Fatal(err)
+	}
+	idJWT, _ := strconv.Atoi(newMessage.IDJWT)
+
+	queue := ts.Queue()
+	if len(queue) != 1 || queue[0].IDJWT != idJWT || queue[0].Datatype != message.DatatypeSubdivisionOrg {
+		t.Fatalf("Queue() = %+v", queue)
+	}
+
+	data, err = ts.Client.Send(ctx, message.NewInfoAllMessage()).Data()
+	if err != nil || string(data) != `{"Messages":[`+newMessage.IDJWT+`]}` {
+		t.Errorf("InfoAll data = %s, error = %v", data, err)
+	}
+
+	data, err = ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, idJWT)).Data()
+	if err != nil {
+		t.Errorf("Info data = %s, error = %v", data, err)
+	}
+
+	data, err = ts.Client.Send(ctx, message.NewConfirmMessage(ts.Crypto, idJWT)).Data()
+	if err != nil {
+		t.Errorf("Confirm data = %s, error = %v", data, err)
+	}
+	if msg, ok := ts.Message(idJWT); !ok || !msg.Confirmed {
+		t.Errorf("Message() = %+v, %v", msg, ok)
+	}
+	if len(ts.Queue()) != 0 {
+		t.Errorf("Queue() confirmed message must leave the queue")
+	}
+
+	if _, err = ts.Client.Send(ctx, message.NewInfoMessage(ts.Crypto, idJWT)).Data(); err == nil {
+		t.Errorf("Info confirmed message must fail")
+	}
+}
+
+func TestStart_badSign(t *testing.T) {
+	server, err := NewServerDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.NewGostCrypto(crypto.SetCert(server.Cert), crypto.SetKey(server.Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := Start(t, SetServer(server), SetClientCrypto(other))
+	data, err := ts.Client.Send(context.Background(), message.NewActionMessage(ts.Crypto, message.ActionAdd, message.DatatypeCampaign, []byte("<PackageData/>"))).Data()
+	if err == nil {
+		t.Errorf("Add data = %s, must fail with the certificate of other organization", data)
+	}
+	if len(ts.Queue()) != 0 {
+		t.Errorf("Queue() must be empty")
+	}
+
+	if err = ts.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err = ts.Close(); err != nil {
+		t.Errorf("Close() twice error = %v", err)
+	}
+}
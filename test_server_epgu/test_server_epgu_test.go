@@ -0,0 +1,142 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package test_server_epgu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ftomza/go-sspvo/message"
+)
+
+func TestClsFixture_generated(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("fixtures", "cls", "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(clsFixtures) {
+		t.Errorf("clsFixtures len = %v, want %v, run go generate", len(clsFixtures), len(files))
+	}
+	for _, file := range files {
+		want, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := ClsFixture(message.CLS(strings.TrimSuffix(filepath.Base(file), ".xml")))
+		if !ok || !bytes.Equal(got, want) {
+			t.Errorf("ClsFixture(%v) is out of date, run go generate", file)
+		}
+	}
+}
+
+func TestDefaultClsFixtures(t *testing.T) {
+	s, err := NewServerDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cls := range message.AllCLS {
+		t.Run(string(cls), func(t *testing.T) {
+			data, ok, err := s.CLS(cls)
+			if err != nil || !ok {
+				t.Fatalf("CLS() ok = %v, error = %v", ok, err)
+			}
+
+			var root struct {
+				XMLName xml.Name
+				Items   []struct {
+					ID string `xml:"ID"`
+				} `xml:",any"`
+			}
+			if err = xml.Unmarshal(data, &root); err != nil {
+				t.Fatalf("CLS() not XML: %v", err)
+			}
+			if root.XMLName.Local != string(cls) {
+				t.Errorf("CLS() root = %v, want %v", root.XMLName.Local, cls)
+			}
+			if len(root.Items) == 0 {
+				t.Errorf("CLS() no items")
+			}
+			for _, item := range root.Items {
+				if item.ID == "" {
+					t.Errorf("CLS() item without ID")
+				}
+			}
+		})
+	}
+}
+
+func TestServer_apiClsRequest(t *testing.T) {
+	s, err := NewServerDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetCLS(message.CLSGenders, []byte("<Genders><Gender><ID>9</ID></Gender></Genders>"))
+	app, err := s.app()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "ok fixture",
+			body:       `{"OGRN":"test","KPP":"test","CLS":"LevelBudget"}`,
+			wantStatus: 200,
+			wantBody:   "<Name>Федеральный</Name>",
+		},
+		{
+			name:       "ok override",
+			body:       `{"OGRN":"test","KPP":"test","CLS":"Genders"}`,
+			wantStatus: 200,
+			wantBody:   "<ID>9</ID>",
+		},
+		{
+			name:       "fail unknown",
+			body:       `{"OGRN":"test","KPP":"test","CLS":"Unknown"}`,
+			wantStatus: 404,
+			wantBody:   "Неизвестный тип классификатора",
+		},
+		{
+			name:       "fail auth",
+			body:       `{"OGRN":"bad","KPP":"test","CLS":"LevelBudget"}`,
+			wantStatus: 404,
+			wantBody:   "не найдена",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/cls/request", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("apiClsRequest() status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			if !bytes.Contains(body, []byte(tt.wantBody)) {
+				t.Errorf("apiClsRequest() body = %s, want %v", body, tt.wantBody)
+			}
+		})
+	}
+
+	s.DeleteCLS(message.CLSGenders)
+	if data, _, _ := s.CLS(message.CLSGenders); bytes.Contains(data, []byte("<ID>9</ID>")) {
+		t.Errorf("DeleteCLS() override not removed")
+	}
+}
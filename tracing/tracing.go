@@ -0,0 +1,90 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+//Package tracing OpenTelemetry spans of signing, sending and verification of the messages.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ftomza/go-sspvo"
+
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+)
+
+//InstrumentationName Name of the tracer of the library.
+const InstrumentationName = "github.com/ftomza/go-sspvo"
+
+//Names of the spans.
+const (
+	SpanSend   = "sspvo.Send"
+	SpanSign   = "sspvo.Sign"
+	SpanPost   = "sspvo.POST"
+	SpanVerify = "sspvo.Verify"
+)
+
+//Attributes of the spans.
+const (
+	AttrPathMethod = label.Key("sspvo.path_method")
+	AttrAction     = label.Key("sspvo.action")
+	AttrDatatype   = label.Key("sspvo.data_type")
+	AttrCLS        = label.Key("sspvo.cls")
+	AttrIDJWT      = label.Key("sspvo.idjwt")
+	AttrVerified   = label.Key("sspvo.verified")
+	AttrStatusCode = label.Key("http.status_code")
+)
+
+var fieldAttrs = []struct {
+	field sspvo.FieldName
+	attr  label.Key
+}{
+	{field: sspvo.FieldAction, attr: AttrAction},
+	{field: sspvo.FieldDataType, attr: AttrDatatype},
+	{field: sspvo.FieldCLS, attr: AttrCLS},
+	{field: sspvo.FieldIdJWT, attr: AttrIDJWT},
+}
+
+//Start Start the child span of the span in the context with the tracer of that span,
+//so nothing is recorded unless the sending is traced by the client.
+func Start(ctx context.Context, name string, attrs ...label.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return trace.SpanFromContext(ctx).Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+//FieldsAttributes Receive the attributes of the JWT fields of the message, the payload is never included.
+func FieldsAttributes(fields sspvo.JWTFields) []label.KeyValue {
+	var attrs []label.KeyValue
+	for _, f := range fieldAttrs {
+		v, ok := fields[f.field]
+		if !ok || v == nil {
+			continue
+		}
+		switch value := v.(type) {
+		case int:
+			attrs = append(attrs, f.attr.Int(value))
+		case float64:
+			attrs = append(attrs, f.attr.Int64(int64(value)))
+		default:
+			attrs = append(attrs, f.attr.String(fmt.Sprint(value)))
+		}
+	}
+	return attrs
+}
+
+//End Set the status of the span by the error and end it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(context.Background(), err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright © 2020-present Artem V. Zaborskiy <ftomza@yandex.ru>. All rights reserved.
+ *
+ * This source code is licensed under the Apache 2.0 license found
+ * in the LICENSE file in the root directory of this source tree.
+ */
+
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/ftomza/go-sspvo"
+	"github.com/ftomza/go-sspvo/client"
+	"github.com/ftomza/go-sspvo/message"
+	"github.com/ftomza/go-sspvo/test_server_epgu"
+	"github.com/ftomza/go-sspvo/tracing"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/tracetest"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+)
+
+func TestFieldsAttributes(t *testing.T) {
+	got := tracing.FieldsAttributes(sspvo.JWTFields{
+		sspvo.FieldAction:   "Add",
+		sspvo.FieldDataType: "campaign",
+		sspvo.FieldIdJWT:    float64(7),
+		sspvo.FieldOGRN:     "ignored",
+	})
+	want := []label.KeyValue{
+		tracing.AttrAction.String("Add"),
+		tracing.AttrDatatype.String("campaign"),
+		tracing.AttrIDJWT.Int64(7),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("FieldsAttributes() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("FieldsAttributes()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStart_untraced(t *testing.T) {
+	_, span := tracing.Start(context.Background(), tracing.SpanSign)
+	if span.IsRecording() {
+		t.Errorf("Start() without the parent span must not record")
+	}
+	tracing.End(span, nil)
+}
+
+func spansByName(spans []*tracetest.Span) map[string][]*tracetest.Span {
+	res := map[string][]*tracetest.Span{}
+	for _, span := range spans {
+		res[span.Name()] = append(res[span.Name()], span)
+	}
+	return res
+}
+
+func TestClient_tracing(t *testing.T) {
+	ts := test_server_epgu.Start(t)
+	recorder := &tracetest.StandardSpanRecorder{}
+	provider := tracetest.NewTracerProvider(tracetest.WithSpanRecorder(recorder))
+
+	sspvoClient, err := client.NewRestyClient(resty.New(),
+		client.SetAPIBase(ts.BaseURL+"/api"),
+		client.SetOGRN(ts.OGRN),
+		client.SetKPP(ts.KPP),
+		client.SetTracerProvider(provider),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, root := provider.Tracer("test").Start(context.Background(), "upload")
+	data, err := sspvoClient.Send(ctx, message.NewActionMessage(ts.Crypto, message.ActionAdd, message.DatatypeCampaign,
+		[]byte("<PackageData><Campaign><UID>C1</UID></Campaign></PackageData>"))).Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newMessage := struct {
+		IDJWT string `json:"IDJWT"`
+	}{}
+	if err = json.Unmarshal(data, &newMessage); err != nil {
+		t.Fatal(err)
+	}
+	idJWT, _ := strconv.Atoi(newMessage.IDJWT)
+
+	ts.SetFault(test_server_epgu.EndpointTokenInfo, test_server_epgu.Fault{InvalidSignature: true})
+	info := sspvoClient.Send(ctx, message.NewInfoMessage(ts.Crypto, idJWT))
+	for i := 0; i < 2; i++ {
+		if _, err = info.Data(); err == nil {
+			t.Fatal("Info with invalid signature must fail")
+		}
+	}
+	root.End()
+
+	spans := spansByName(recorder.Completed())
+	if len(spans[tracing.SpanSend]) != 2 || len(spans[tracing.SpanSign]) != 2 || len(spans[tracing.SpanPost]) != 2 ||
+		len(spans[tracing.SpanVerify]) != 1 {
+		t.Fatalf("spans = %v", spans)
+	}
+
+	add := spans[tracing.SpanSend][0]
+	if add.ParentSpanID() != root.SpanContext().SpanID || add.SpanKind() != trace.SpanKindClient {
+		t.Errorf("Send span parent = %v, kind = %v", add.ParentSpanID(), add.SpanKind())
+	}
+	attrs := add.Attributes()
+	if attrs[tracing.AttrDatatype].AsString() != "campaign" || attrs[tracing.AttrAction].AsString() != "Add" ||
+		attrs[tracing.AttrStatusCode].AsInt64() != 200 || attrs[tracing.AttrPathMethod].AsString() != "token/new" {
+		t.Errorf("Send span attributes = %v", attrs)
+	}
+	for _, name := range []string{tracing.SpanSign, tracing.SpanPost} {
+		if spans[name][0].ParentSpanID() != add.SpanContext().SpanID {
+			t.Errorf("%s span parent = %v, want %v", name, spans[name][0].ParentSpanID(), add.SpanContext().SpanID)
+		}
+	}
+
+	verify := spans[tracing.SpanVerify][0]
+	if verify.ParentSpanID() != spans[tracing.SpanSend][1].SpanContext().SpanID || verify.StatusCode() != codes.Error {
+		t.Errorf("Verify span parent = %v, status = %v", verify.ParentSpanID(), verify.StatusCode())
+	}
+	verifyEnd, _ := verify.EndTime()
+	sendEnd, _ := spans[tracing.SpanSend][1].EndTime()
+	if verifyEnd.After(sendEnd) {
+		t.Errorf("Verify span ended at %v after Send span %v", verifyEnd, sendEnd)
+	}
+	attrs = verify.Attributes()
+	if attrs[tracing.AttrVerified].AsBool() || attrs[tracing.AttrIDJWT].AsInt64() != int64(idJWT) ||
+		attrs[tracing.AttrDatatype].AsString() != "campaign" {
+		t.Errorf("Verify span attributes = %v", attrs)
+	}
+}